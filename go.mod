@@ -3,6 +3,7 @@ module github.com/extended-protocol/extended-sdk-golang
 go 1.24.0
 
 require (
+	github.com/gorilla/websocket v1.5.3
 	github.com/joho/godotenv v1.5.1
 	github.com/shopspring/decimal v1.4.0
 	github.com/stretchr/testify v1.11.1
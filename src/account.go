@@ -0,0 +1,46 @@
+package sdk
+
+import "github.com/shopspring/decimal"
+
+// BalanceModel represents the account's collateral and margin state
+type BalanceModel struct {
+	CollateralName         string          `json:"collateralName"`
+	Balance                decimal.Decimal `json:"balance"`
+	Equity                 decimal.Decimal `json:"equity"`
+	AvailableForTrade      decimal.Decimal `json:"availableForTrade"`
+	AvailableForWithdrawal decimal.Decimal `json:"availableForWithdrawal"`
+	UnrealisedPnl          decimal.Decimal `json:"unrealisedPnl"`
+	InitialMargin          decimal.Decimal `json:"initialMargin"`
+	MaintenanceMargin      decimal.Decimal `json:"maintenanceMargin"`
+	MarginRatio            decimal.Decimal `json:"marginRatio"`
+	Exposure               decimal.Decimal `json:"exposure"`
+	Leverage               decimal.Decimal `json:"leverage"`
+	UpdatedTime            int64           `json:"updatedTime"`
+}
+
+// AccountLeverageModel represents the leverage configured for a market
+type AccountLeverageModel struct {
+	Market   string          `json:"market"`
+	Leverage decimal.Decimal `json:"leverage"`
+}
+
+// AccountUpdateType identifies the kind of change pushed on the account stream
+type AccountUpdateType string
+
+const (
+	AccountUpdateOrder    AccountUpdateType = "ORDER"
+	AccountUpdateTrade    AccountUpdateType = "TRADE"
+	AccountUpdatePosition AccountUpdateType = "POSITION"
+	AccountUpdateBalance  AccountUpdateType = "BALANCE"
+)
+
+// AccountUpdate is a message of the private account stream. Only the field matching Type is set.
+type AccountUpdate struct {
+	Type      AccountUpdateType
+	Orders    []OrderModel
+	Trades    []TradeModel
+	Positions []PositionModel
+	Balance   *BalanceModel
+	Seq       int64
+	Timestamp int64
+}
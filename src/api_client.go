@@ -4,27 +4,81 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"iter"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 // APIClient provides REST API functionality for perpetual trading
 // It embeds BaseModule to reuse common functionality like HTTP client, auth, etc.
 type APIClient struct {
 	*BaseModule
+	fees *feeCache
+}
+
+// NewClient creates a new API client for an environment, usually MainnetConfig() or TestnetConfig()
+func NewClient(cfg EndpointConfig, opts ...ClientOption) *APIClient {
+	options := clientOptions{clientTimeout: DefaultClientTimeout}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	apiKey := options.apiKey
+	if !options.apiKeySet && options.starkAccount != nil {
+		apiKey = options.starkAccount.APIKey()
+	}
+
+	baseModule := NewBaseModule(cfg, apiKey, options.starkAccount, options.buildHTTPClient(options.clientTimeout), options.clientTimeout)
+	return &APIClient{
+		BaseModule: baseModule,
+		fees:       newFeeCache(DefaultFeeCacheTTL),
+	}
 }
 
 // NewAPIClient creates a new API client instance
+//
+// Deprecated: use NewClient with WithAPIKey, WithStarkAccount and WithTimeout.
 func NewAPIClient(
 	cfg EndpointConfig,
 	apiKey string,
 	starkAccount *StarkPerpetualAccount,
 	clientTimeout time.Duration,
+	opts ...ClientOption,
 ) *APIClient {
-	baseModule := NewBaseModule(cfg, apiKey, starkAccount, nil, clientTimeout)
-	return &APIClient{
-		BaseModule: baseModule,
+	opts = append([]ClientOption{WithAPIKey(apiKey), WithStarkAccount(starkAccount), WithTimeout(clientTimeout)}, opts...)
+	return NewClient(cfg, opts...)
+}
+
+// StarknetDomain returns the domain orders sent through this client must be signed for
+func (c *APIClient) StarknetDomain() StarknetDomain {
+	return c.EndpointConfig().StarknetDomain
+}
+
+// CreateOrder creates an order signed for the client's Starknet domain. params.StarknetDomain
+// may be left empty; an order for another domain is rejected.
+func (c *APIClient) CreateOrder(params CreateOrderObjectParams) (*PerpetualOrderModel, error) {
+	domain := c.StarknetDomain()
+	if domain == (StarknetDomain{}) {
+		return nil, fmt.Errorf("endpoint config has no starknet domain")
+	}
+	if params.StarknetDomain != (StarknetDomain{}) && params.StarknetDomain != domain {
+		return nil, fmt.Errorf("order starknet domain %s does not match the client's %s", params.StarknetDomain.ChainID, domain.ChainID)
 	}
+	params.StarknetDomain = domain
+	return CreateOrderObject(params)
+}
+
+// PaginationModel is returned by paged endpoints. Cursor is nil on the last page.
+type PaginationModel struct {
+	Cursor *int64 `json:"cursor"`
+	Count  int    `json:"count"`
 }
 
 // ===== Market Data Operations =====
@@ -53,12 +107,28 @@ func (c *APIClient) GetMarkets(ctx context.Context, market []string) ([]MarketMo
 		return nil, err
 	}
 
-	// Check API status
-	if marketResponse.Status != "OK" {
-		return nil, fmt.Errorf("API returned error status: %s", marketResponse.Status)
+	return marketResponse.Data, nil
+}
+
+// MarketStatsResponse represents the API response for a market's statistics
+type MarketStatsResponse struct {
+	Data   MarketStatsModel `json:"data"`
+	Status string           `json:"status"`
+}
+
+// GetMarketStats retrieves the latest prices, 24h statistics, open interest and funding of a market
+func (c *APIClient) GetMarketStats(ctx context.Context, market string) (*MarketStatsModel, error) {
+	baseUrl, err := c.GetURL("/info/markets/"+url.PathEscape(market)+"/stats", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var statsResponse MarketStatsResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseUrl, nil, &statsResponse); err != nil {
+		return nil, err
 	}
 
-	return marketResponse.Data, nil
+	return &statsResponse.Data, nil
 }
 
 // ===== Fee Data Operations =====
@@ -82,11 +152,73 @@ func (c *APIClient) GetMarketFee(ctx context.Context, market string) ([]TradingF
 		return nil, err
 	}
 
-	if feeResponse.Status != "OK" {
-		return nil, fmt.Errorf("API returned error status: %v", feeResponse.Status)
+	return feeResponse.Data, nil
+}
+
+// DefaultFeeCacheTTL is how long fees fetched by MarketFee are reused before being refreshed
+const DefaultFeeCacheTTL = 5 * time.Minute
+
+type cachedFee struct {
+	fee       TradingFeeModel
+	fetchedAt time.Time
+}
+
+// feeCache holds per-market fees so that order creation does not hit /user/fees every time
+type feeCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cachedFee
+}
+
+func newFeeCache(ttl time.Duration) *feeCache {
+	return &feeCache{
+		ttl:     ttl,
+		entries: make(map[string]cachedFee),
 	}
+}
 
-	return feeResponse.Data, nil
+// SetFeeCacheTTL changes how long cached fees stay valid. A zero TTL disables caching.
+func (c *APIClient) SetFeeCacheTTL(ttl time.Duration) {
+	c.fees.mu.Lock()
+	defer c.fees.mu.Unlock()
+	c.fees.ttl = ttl
+}
+
+// MarketFee returns the account's fees for a market, fetching them from GetMarketFee
+// when they are not cached or the cached value has expired.
+// The result can be passed as CreateOrderObjectParams.Fees.
+func (c *APIClient) MarketFee(ctx context.Context, market string) (*TradingFeeModel, error) {
+	c.fees.mu.Lock()
+	entry, ok := c.fees.entries[market]
+	ttl := c.fees.ttl
+	c.fees.mu.Unlock()
+
+	if ok && time.Since(entry.fetchedAt) < ttl {
+		fee := entry.fee
+		return &fee, nil
+	}
+
+	return c.RefreshMarketFee(ctx, market)
+}
+
+// RefreshMarketFee fetches the account's fees for a market and updates the cache
+func (c *APIClient) RefreshMarketFee(ctx context.Context, market string) (*TradingFeeModel, error) {
+	fees, err := c.GetMarketFee(ctx, market)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := slices.IndexFunc(fees, func(f TradingFeeModel) bool { return f.Market == market })
+	if idx < 0 {
+		return nil, fmt.Errorf("no fees returned for market %s", market)
+	}
+	fee := fees[idx]
+
+	c.fees.mu.Lock()
+	c.fees.entries[market] = cachedFee{fee: fee, fetchedAt: time.Now()}
+	c.fees.mu.Unlock()
+
+	return &fee, nil
 }
 
 // ===== Order Operations =====
@@ -107,13 +239,21 @@ type OrderResponse struct {
 	}
 }
 
-// SubmitOrder submits a perpetual order to the trading API
+// SubmitOrder submits a perpetual order to the trading API. Orders with an external ID are
+// retried like idempotent requests. If a retry is rejected, which the exchange does for an
+// external ID it has already seen, the order is looked up with GetOrderByExternalID and
+// reported as submitted when it exists.
 func (c *APIClient) SubmitOrder(ctx context.Context, order *PerpetualOrderModel) (*OrderResponse, error) {
 	// Validate order object is complete and properly signed
 	if order == nil {
 		return nil, fmt.Errorf("order is nil")
 	}
 
+	// An order signed for another environment would be rejected with an invalid signature
+	if domain := c.StarknetDomain(); order.domain != (StarknetDomain{}) && domain != (StarknetDomain{}) && order.domain != domain {
+		return nil, fmt.Errorf("order was signed for starknet domain %s, but the client is configured for %s", order.domain.ChainID, domain.ChainID)
+	}
+
 	baseUrl, err := c.GetURL("/user/order", nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build URL: %w", err)
@@ -128,14 +268,27 @@ func (c *APIClient) SubmitOrder(ctx context.Context, order *PerpetualOrderModel)
 	// Create a buffer with the JSON data
 	jsonData := bytes.NewBuffer(orderJSON)
 
-	// Use the new DoRequest method to handle the HTTP request and JSON parsing
-	var orderResponse OrderResponse
-	if err := c.BaseModule.DoRequest(ctx, "POST", baseUrl, jsonData, &orderResponse); err != nil {
-		return nil, err
+	// The exchange rejects a second order with the same external ID, so a resubmission cannot
+	// place the order twice and the request is safe to retry
+	if order.ID != "" {
+		ctx = withIdempotentRequest(ctx)
 	}
 
-	if orderResponse.Status != "OK" {
-		return nil, fmt.Errorf("API returned error status: %v", orderResponse.Status)
+	var orderResponse OrderResponse
+	attempts, err := c.BaseModule.doRequest(ctx, "POST", baseUrl, jsonData, &orderResponse)
+	if err != nil {
+		// An earlier attempt may have placed the order before failing, in which case the
+		// retry is rejected as a duplicate
+		var apiErr *APIError
+		if attempts > 1 && errors.As(err, &apiErr) && !apiErr.Temporary() {
+			if placed, lookupErr := c.GetOrderByExternalID(ctx, order.ID); lookupErr == nil {
+				orderResponse.Status = "OK"
+				orderResponse.Data.OrderID = placed.ID
+				orderResponse.Data.ExternalID = placed.ExternalID
+				return &orderResponse, nil
+			}
+		}
+		return nil, err
 	}
 
 	if orderResponse.Data.ExternalID != order.ID {
@@ -144,3 +297,738 @@ func (c *APIClient) SubmitOrder(ctx context.Context, order *PerpetualOrderModel)
 
 	return &orderResponse, nil
 }
+
+// ===== Order Cancellation Operations =====
+
+// CancelOrderResponse represents the API response after a cancellation request
+type CancelOrderResponse struct {
+	Status string `json:"status"`
+}
+
+// MassCancelParams selects the orders to cancel. Orders matching any of the
+// filters are cancelled.
+type MassCancelParams struct {
+	OrderIDs         []uint
+	ExternalOrderIDs []string
+	Markets          []string
+	// Side restricts every other filter to one side of the book and requires Markets
+	// or CancelAll. The exchange has no native side filter, so the matching open orders
+	// are looked up first and cancelled by ID.
+	Side      *OrderSide
+	CancelAll bool
+}
+
+// massCancelRequest is the body of the mass cancel endpoint
+type massCancelRequest struct {
+	OrderIDs         []uint   `json:"orderIds,omitempty"`
+	ExternalOrderIDs []string `json:"externalOrderIds,omitempty"`
+	Markets          []string `json:"markets,omitempty"`
+	CancelAll        bool     `json:"cancelAll,omitempty"`
+}
+
+func (c *APIClient) cancelOrder(ctx context.Context, url string) (*CancelOrderResponse, error) {
+	var cancelResponse CancelOrderResponse
+	if err := c.BaseModule.DoRequest(ctx, "DELETE", url, nil, &cancelResponse); err != nil {
+		return nil, err
+	}
+
+	return &cancelResponse, nil
+}
+
+// CancelOrderByID cancels an open order using the exchange assigned order ID
+func (c *APIClient) CancelOrderByID(ctx context.Context, orderID uint) (*CancelOrderResponse, error) {
+	baseUrl, err := c.GetURL(fmt.Sprintf("/user/order/%d", orderID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	return c.cancelOrder(ctx, baseUrl)
+}
+
+// CancelOrderByExternalID cancels an open order using the external ID set on the PerpetualOrderModel
+func (c *APIClient) CancelOrderByExternalID(ctx context.Context, externalID string) (*CancelOrderResponse, error) {
+	if externalID == "" {
+		return nil, fmt.Errorf("external order ID is empty")
+	}
+
+	baseUrl, err := c.GetURL("/user/order", map[string]string{"externalId": externalID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	return c.cancelOrder(ctx, baseUrl)
+}
+
+// MassCancel cancels every open order matching the given filters. With a Side, only the
+// orders open when MassCancel looks them up are cancelled; orders placed in the meantime
+// survive, so call it again or cancel without a side when the book must be flat.
+func (c *APIClient) MassCancel(ctx context.Context, params MassCancelParams) (*CancelOrderResponse, error) {
+	request := massCancelRequest{
+		OrderIDs:         params.OrderIDs,
+		ExternalOrderIDs: params.ExternalOrderIDs,
+		Markets:          params.Markets,
+		CancelAll:        params.CancelAll,
+	}
+
+	if params.Side != nil {
+		if len(params.Markets) == 0 && !params.CancelAll {
+			return nil, fmt.Errorf("mass cancel by side requires markets or cancel all")
+		}
+		orderIDs, err := c.openOrderIDsOnSide(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if len(orderIDs) == 0 {
+			return &CancelOrderResponse{Status: "OK"}, nil
+		}
+		request = massCancelRequest{OrderIDs: orderIDs}
+	}
+
+	if len(request.OrderIDs) == 0 && len(request.ExternalOrderIDs) == 0 && len(request.Markets) == 0 && !request.CancelAll {
+		return nil, fmt.Errorf("mass cancel requires at least one filter")
+	}
+
+	baseUrl, err := c.GetURL("/user/order/massCancel", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal mass cancel request to JSON: %w", err)
+	}
+
+	var cancelResponse CancelOrderResponse
+	// Cancelling the same orders twice has no further effect
+	if err := c.BaseModule.DoRequest(withIdempotentRequest(ctx), "POST", baseUrl, bytes.NewBuffer(requestJSON), &cancelResponse); err != nil {
+		return nil, err
+	}
+
+	return &cancelResponse, nil
+}
+
+// openOrderIDsOnSide returns the IDs of the open orders on params.Side that match any other filter
+func (c *APIClient) openOrderIDsOnSide(ctx context.Context, params MassCancelParams) ([]uint, error) {
+	// Explicit orders may be in any market
+	lookup := OpenOrdersParams{Side: params.Side}
+	if !params.CancelAll && len(params.OrderIDs) == 0 && len(params.ExternalOrderIDs) == 0 {
+		lookup.Markets = params.Markets
+	}
+	openOrders, err := c.GetOpenOrders(ctx, lookup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open orders: %w", err)
+	}
+
+	var orderIDs []uint
+	for _, order := range openOrders {
+		if params.CancelAll ||
+			slices.Contains(params.Markets, order.Market) ||
+			slices.Contains(params.OrderIDs, order.ID) ||
+			slices.Contains(params.ExternalOrderIDs, order.ExternalID) {
+			orderIDs = append(orderIDs, order.ID)
+		}
+	}
+	return orderIDs, nil
+}
+
+// CancelAllOrders cancels every open order on the account
+func (c *APIClient) CancelAllOrders(ctx context.Context) (*CancelOrderResponse, error) {
+	return c.MassCancel(ctx, MassCancelParams{CancelAll: true})
+}
+
+// ===== Order Query Operations =====
+
+// OrdersResponse represents the API response for order lists
+type OrdersResponse struct {
+	Data       []OrderModel     `json:"data"`
+	Status     string           `json:"status"`
+	Pagination *PaginationModel `json:"pagination,omitempty"`
+}
+
+// SingleOrderResponse represents the API response for a single order
+type SingleOrderResponse struct {
+	Data   OrderModel `json:"data"`
+	Status string     `json:"status"`
+}
+
+// OpenOrdersParams filters the open orders query. Empty fields match everything.
+type OpenOrdersParams struct {
+	Markets []string
+	Type    *OrderType
+	Side    *OrderSide
+}
+
+// OrdersHistoryParams filters and pages the order history query.
+// Pass the cursor of the previous page to fetch the next one.
+type OrdersHistoryParams struct {
+	Markets []string
+	Type    *OrderType
+	Side    *OrderSide
+	Cursor  *int64
+	Limit   int
+}
+
+func orderFilterQuery(markets []string, orderType *OrderType, side *OrderSide) url.Values {
+	query := url.Values{}
+	for _, market := range markets {
+		query.Add("market", market)
+	}
+	if orderType != nil {
+		query.Set("type", string(*orderType))
+	}
+	if side != nil {
+		query.Set("side", string(*side))
+	}
+	return query
+}
+
+func (c *APIClient) getOrders(ctx context.Context, path string, query url.Values) (*OrdersResponse, error) {
+	baseUrl, err := c.GetURLWithQuery(path, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var ordersResponse OrdersResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseUrl, nil, &ordersResponse); err != nil {
+		return nil, err
+	}
+
+	return &ordersResponse, nil
+}
+
+// GetOpenOrders retrieves the account's open orders
+func (c *APIClient) GetOpenOrders(ctx context.Context, params OpenOrdersParams) ([]OrderModel, error) {
+	ordersResponse, err := c.getOrders(ctx, "/user/orders", orderFilterQuery(params.Markets, params.Type, params.Side))
+	if err != nil {
+		return nil, err
+	}
+
+	return ordersResponse.Data, nil
+}
+
+// GetOrdersHistory retrieves one page of the account's order history. The returned
+// pagination holds the cursor for the next page, which is nil once there are no more.
+func (c *APIClient) GetOrdersHistory(ctx context.Context, params OrdersHistoryParams) ([]OrderModel, *PaginationModel, error) {
+	query := orderFilterQuery(params.Markets, params.Type, params.Side)
+	if params.Cursor != nil {
+		query.Set("cursor", strconv.FormatInt(*params.Cursor, 10))
+	}
+	if params.Limit > 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+
+	ordersResponse, err := c.getOrders(ctx, "/user/orders/history", query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pagination := ordersResponse.Pagination
+	if pagination == nil {
+		pagination = &PaginationModel{Count: len(ordersResponse.Data)}
+	}
+
+	return ordersResponse.Data, pagination, nil
+}
+
+// GetOrderByID retrieves a single order using the exchange assigned order ID
+func (c *APIClient) GetOrderByID(ctx context.Context, orderID uint) (*OrderModel, error) {
+	baseUrl, err := c.GetURL(fmt.Sprintf("/user/orders/%d", orderID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var orderResponse SingleOrderResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseUrl, nil, &orderResponse); err != nil {
+		return nil, err
+	}
+
+	return &orderResponse.Data, nil
+}
+
+// GetOrderByExternalID retrieves a single order using the external ID set by CreateOrderObject
+func (c *APIClient) GetOrderByExternalID(ctx context.Context, externalID string) (*OrderModel, error) {
+	if externalID == "" {
+		return nil, fmt.Errorf("external order ID is empty")
+	}
+
+	ordersResponse, err := c.getOrders(ctx, "/user/orders/external/"+url.PathEscape(externalID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ordersResponse.Data) == 0 {
+		return nil, fmt.Errorf("no order found with external ID %s", externalID)
+	}
+
+	return &ordersResponse.Data[0], nil
+}
+
+// ===== Position Operations =====
+
+// PositionsResponse represents the API response for open positions
+type PositionsResponse struct {
+	Data   []PositionModel `json:"data"`
+	Status string          `json:"status"`
+}
+
+// PositionsHistoryResponse represents the API response for position history
+type PositionsHistoryResponse struct {
+	Data       []PositionHistoryModel `json:"data"`
+	Status     string                 `json:"status"`
+	Pagination *PaginationModel       `json:"pagination,omitempty"`
+}
+
+// PositionsHistoryParams filters and pages the position history query
+type PositionsHistoryParams struct {
+	Markets []string
+	Side    *PositionSide
+	Cursor  *int64
+	Limit   int
+}
+
+func positionFilterQuery(markets []string, side *PositionSide) url.Values {
+	query := url.Values{}
+	for _, market := range markets {
+		query.Add("market", market)
+	}
+	if side != nil {
+		query.Set("side", string(*side))
+	}
+	return query
+}
+
+// GetPositions retrieves the account's open positions, optionally filtered by market and side
+func (c *APIClient) GetPositions(ctx context.Context, markets []string, side *PositionSide) ([]PositionModel, error) {
+	baseUrl, err := c.GetURLWithQuery("/user/positions", positionFilterQuery(markets, side))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var positionsResponse PositionsResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseUrl, nil, &positionsResponse); err != nil {
+		return nil, err
+	}
+
+	return positionsResponse.Data, nil
+}
+
+// GetPositionsHistory retrieves one page of the account's position history
+func (c *APIClient) GetPositionsHistory(ctx context.Context, params PositionsHistoryParams) ([]PositionHistoryModel, *PaginationModel, error) {
+	query := positionFilterQuery(params.Markets, params.Side)
+	if params.Cursor != nil {
+		query.Set("cursor", strconv.FormatInt(*params.Cursor, 10))
+	}
+	if params.Limit > 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+
+	baseUrl, err := c.GetURLWithQuery("/user/positions/history", query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var historyResponse PositionsHistoryResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseUrl, nil, &historyResponse); err != nil {
+		return nil, nil, err
+	}
+
+	pagination := historyResponse.Pagination
+	if pagination == nil {
+		pagination = &PaginationModel{Count: len(historyResponse.Data)}
+	}
+
+	return historyResponse.Data, pagination, nil
+}
+
+// ===== Account Operations =====
+
+// BalanceResponse represents the API response for the account balance
+type BalanceResponse struct {
+	Data   BalanceModel `json:"data"`
+	Status string       `json:"status"`
+}
+
+// GetBalance retrieves the account's equity, available collateral and margin usage
+func (c *APIClient) GetBalance(ctx context.Context) (*BalanceModel, error) {
+	baseUrl, err := c.GetURL("/user/balance", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var balanceResponse BalanceResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseUrl, nil, &balanceResponse); err != nil {
+		return nil, err
+	}
+
+	return &balanceResponse.Data, nil
+}
+
+// ===== Trade Operations =====
+
+// TradesResponse represents the API response for the account's trades
+type TradesResponse struct {
+	Data       []TradeModel     `json:"data"`
+	Status     string           `json:"status"`
+	Pagination *PaginationModel `json:"pagination,omitempty"`
+}
+
+// TradesParams filters the trade history. Start and End bound the trade creation
+// time (inclusive start, exclusive end); zero values leave the range open. The trades
+// endpoint has no time filter, so the range is applied by GetTrades.
+type TradesParams struct {
+	Markets []string
+	Side    *OrderSide
+	Type    *TradeType
+	Start   time.Time
+	End     time.Time
+	// Limit is the page size requested from the API
+	Limit int
+}
+
+func (c *APIClient) getTradesPage(ctx context.Context, params TradesParams, cursor *int64) (*TradesResponse, error) {
+	query := url.Values{}
+	for _, market := range params.Markets {
+		query.Add("market", market)
+	}
+	if params.Side != nil {
+		query.Set("side", string(*params.Side))
+	}
+	if params.Type != nil {
+		query.Set("type", string(*params.Type))
+	}
+	if cursor != nil {
+		query.Set("cursor", strconv.FormatInt(*cursor, 10))
+	}
+	if params.Limit > 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+
+	baseUrl, err := c.GetURLWithQuery("/user/trades", query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var tradesResponse TradesResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseUrl, nil, &tradesResponse); err != nil {
+		return nil, err
+	}
+
+	return &tradesResponse, nil
+}
+
+// GetTrades iterates over the account's trades, newest first, fetching pages as needed.
+// Iteration stops after the first error, which is yielded with a zero TradeModel.
+//
+// The time range is applied client-side: with an End in the past, every newer page is
+// still fetched and discarded before the first trade is yielded. Since the API returns
+// trades newest first, no page is fetched after the first trade older than Start.
+//
+//	for trade, err := range client.GetTrades(ctx, params) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (c *APIClient) GetTrades(ctx context.Context, params TradesParams) iter.Seq2[TradeModel, error] {
+	return func(yield func(TradeModel, error) bool) {
+		var cursor *int64
+		for {
+			page, err := c.getTradesPage(ctx, params, cursor)
+			if err != nil {
+				yield(TradeModel{}, err)
+				return
+			}
+
+			for _, trade := range page.Data {
+				createdAt := time.UnixMilli(trade.CreatedTime)
+				if !params.End.IsZero() && !createdAt.Before(params.End) {
+					continue
+				}
+				if !params.Start.IsZero() && createdAt.Before(params.Start) {
+					// Trades are returned newest first, so everything after this is out of range
+					return
+				}
+				if !yield(trade, nil) {
+					return
+				}
+			}
+
+			if page.Pagination == nil || page.Pagination.Cursor == nil || len(page.Data) == 0 {
+				return
+			}
+			cursor = page.Pagination.Cursor
+		}
+	}
+}
+
+// ===== Funding Operations =====
+
+// FundingPaymentsResponse represents the API response for the account's funding payments
+type FundingPaymentsResponse struct {
+	Data       []FundingPaymentModel `json:"data"`
+	Status     string                `json:"status"`
+	Pagination *PaginationModel      `json:"pagination,omitempty"`
+}
+
+// FundingPaymentsParams filters the funding payment history. Start is required by the
+// exchange; a zero End leaves the range open.
+type FundingPaymentsParams struct {
+	Markets []string
+	Side    *PositionSide
+	Start   time.Time
+	End     time.Time
+	Cursor  *int64
+	Limit   int
+}
+
+// GetFundingPayments retrieves one page of the account's funding payments
+func (c *APIClient) GetFundingPayments(ctx context.Context, params FundingPaymentsParams) ([]FundingPaymentModel, *PaginationModel, error) {
+	if params.Start.IsZero() {
+		return nil, nil, fmt.Errorf("start time must be provided")
+	}
+
+	query := url.Values{}
+	for _, market := range params.Markets {
+		query.Add("market", market)
+	}
+	if params.Side != nil {
+		query.Set("side", string(*params.Side))
+	}
+	query.Set("fromTime", strconv.FormatInt(params.Start.UnixMilli(), 10))
+	if !params.End.IsZero() {
+		query.Set("toTime", strconv.FormatInt(params.End.UnixMilli(), 10))
+	}
+	if params.Cursor != nil {
+		query.Set("cursor", strconv.FormatInt(*params.Cursor, 10))
+	}
+	if params.Limit > 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+
+	baseUrl, err := c.GetURLWithQuery("/user/funding/history", query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var fundingResponse FundingPaymentsResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseUrl, nil, &fundingResponse); err != nil {
+		return nil, nil, err
+	}
+
+	pagination := fundingResponse.Pagination
+	if pagination == nil {
+		pagination = &PaginationModel{Count: len(fundingResponse.Data)}
+	}
+
+	return fundingResponse.Data, pagination, nil
+}
+
+// LeverageResponse represents the API response for leverage settings
+type LeverageResponse struct {
+	Data   []AccountLeverageModel `json:"data"`
+	Status string                 `json:"status"`
+}
+
+// UpdateLeverageResponse represents the API response after changing leverage
+type UpdateLeverageResponse struct {
+	Data   AccountLeverageModel `json:"data"`
+	Status string               `json:"status"`
+}
+
+// GetLeverage retrieves the account's leverage for the given markets, or all markets when empty
+func (c *APIClient) GetLeverage(ctx context.Context, markets []string) ([]AccountLeverageModel, error) {
+	query := url.Values{}
+	for _, market := range markets {
+		query.Add("market", market)
+	}
+
+	baseUrl, err := c.GetURLWithQuery("/user/leverage", query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var leverageResponse LeverageResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseUrl, nil, &leverageResponse); err != nil {
+		return nil, err
+	}
+
+	return leverageResponse.Data, nil
+}
+
+// UpdateLeverage sets the account's leverage for a market. The market is loaded first so that
+// the leverage can be checked against its maximum (see MarketModel.ValidateLeverage).
+func (c *APIClient) UpdateLeverage(ctx context.Context, market string, leverage decimal.Decimal) (*AccountLeverageModel, error) {
+	if !leverage.IsPositive() {
+		return nil, fmt.Errorf("leverage must be positive, got %s", leverage)
+	}
+
+	markets, err := c.GetMarkets(ctx, []string{market})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load market %s: %w", market, err)
+	}
+	if len(markets) == 0 {
+		return nil, fmt.Errorf("market %s not found", market)
+	}
+	if err := markets[0].ValidateLeverage(leverage); err != nil {
+		return nil, err
+	}
+
+	baseUrl, err := c.GetURL("/user/leverage", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	requestJSON, err := json.Marshal(AccountLeverageModel{Market: market, Leverage: leverage})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal leverage request to JSON: %w", err)
+	}
+
+	var leverageResponse UpdateLeverageResponse
+	// The leverage is set to an absolute value, so repeating the request is harmless
+	if err := c.BaseModule.DoRequest(withIdempotentRequest(ctx), "PATCH", baseUrl, bytes.NewBuffer(requestJSON), &leverageResponse); err != nil {
+		return nil, err
+	}
+
+	return &leverageResponse.Data, nil
+}
+
+// ===== Order Book Operations =====
+
+// OrderbookResponse represents the API response for an order book snapshot
+type OrderbookResponse struct {
+	Data   OrderbookModel `json:"data"`
+	Status string         `json:"status"`
+}
+
+// GetOrderbook retrieves an order book snapshot for a market
+func (c *APIClient) GetOrderbook(ctx context.Context, market string) (*OrderbookModel, error) {
+	baseUrl, err := c.GetURL("/info/markets/"+url.PathEscape(market)+"/orderbook", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var orderbookResponse OrderbookResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseUrl, nil, &orderbookResponse); err != nil {
+		return nil, err
+	}
+
+	return &orderbookResponse.Data, nil
+}
+
+// ===== Public Market Data Operations =====
+
+// PublicTradesResponse represents the API response for a market's recent trades
+type PublicTradesResponse struct {
+	Data   []PublicTradeModel `json:"data"`
+	Status string             `json:"status"`
+}
+
+// CandlesResponse represents the API response for candles
+type CandlesResponse struct {
+	Data   []CandleModel `json:"data"`
+	Status string        `json:"status"`
+}
+
+// FundingRatesResponse represents the API response for funding rate history
+type FundingRatesResponse struct {
+	Data       []FundingRateModel `json:"data"`
+	Status     string             `json:"status"`
+	Pagination *PaginationModel   `json:"pagination,omitempty"`
+}
+
+// CandlesParams selects the candles to fetch. The API returns the Limit candles
+// ending at End (or now when End is zero), newest first.
+type CandlesParams struct {
+	Type     CandleType
+	Interval CandleInterval
+	Limit    int
+	End      time.Time
+}
+
+// FundingRatesParams selects the funding rate history range
+type FundingRatesParams struct {
+	Start  time.Time
+	End    time.Time
+	Cursor *int64
+	Limit  int
+}
+
+// GetPublicTrades retrieves the most recent trades of a market
+func (c *APIClient) GetPublicTrades(ctx context.Context, market string) ([]PublicTradeModel, error) {
+	baseUrl, err := c.GetURL("/info/markets/"+url.PathEscape(market)+"/trades", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var tradesResponse PublicTradesResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseUrl, nil, &tradesResponse); err != nil {
+		return nil, err
+	}
+
+	return tradesResponse.Data, nil
+}
+
+// GetCandles retrieves OHLCV candles for a market
+func (c *APIClient) GetCandles(ctx context.Context, market string, params CandlesParams) ([]CandleModel, error) {
+	if params.Type == "" {
+		params.Type = CandleTypeTrades
+	}
+	if params.Interval == "" {
+		return nil, fmt.Errorf("candle interval must be provided")
+	}
+
+	query := url.Values{}
+	query.Set("interval", string(params.Interval))
+	if params.Limit > 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+	if !params.End.IsZero() {
+		query.Set("endTime", strconv.FormatInt(params.End.UnixMilli(), 10))
+	}
+
+	baseUrl, err := c.GetURLWithQuery("/info/candles/"+url.PathEscape(market)+"/"+string(params.Type), query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var candlesResponse CandlesResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseUrl, nil, &candlesResponse); err != nil {
+		return nil, err
+	}
+
+	return candlesResponse.Data, nil
+}
+
+// GetFundingRates retrieves one page of a market's historical funding rates
+func (c *APIClient) GetFundingRates(ctx context.Context, market string, params FundingRatesParams) ([]FundingRateModel, *PaginationModel, error) {
+	if params.Start.IsZero() || params.End.IsZero() {
+		return nil, nil, fmt.Errorf("start and end time must be provided")
+	}
+
+	query := url.Values{}
+	query.Set("startTime", strconv.FormatInt(params.Start.UnixMilli(), 10))
+	query.Set("endTime", strconv.FormatInt(params.End.UnixMilli(), 10))
+	if params.Cursor != nil {
+		query.Set("cursor", strconv.FormatInt(*params.Cursor, 10))
+	}
+	if params.Limit > 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+
+	baseUrl, err := c.GetURLWithQuery("/info/"+url.PathEscape(market)+"/funding", query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var fundingResponse FundingRatesResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseUrl, nil, &fundingResponse); err != nil {
+		return nil, nil, err
+	}
+
+	pagination := fundingResponse.Pagination
+	if pagination == nil {
+		pagination = &PaginationModel{Count: len(fundingResponse.Data)}
+	}
+
+	return fundingResponse.Data, pagination, nil
+}
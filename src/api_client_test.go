@@ -2,9 +2,14 @@ package sdk
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -32,10 +37,6 @@ func load() {
 	}
 }
 func createTestClient() *APIClient {
-	cfg := EndpointConfig{
-		APIBaseURL: "https://api.starknet.sepolia.extended.exchange/api/v1",
-	}
-
 	apiKey := os.Getenv("TEST_API_KEY")
 	vaultStr := os.Getenv("TEST_VAULT")
 	vault, _ := strconv.ParseUint(vaultStr, 10, 64)
@@ -47,7 +48,19 @@ func createTestClient() *APIClient {
 		panic("Failed to create StarkPerpetualAccount: " + err.Error())
 	}
 
-	return NewAPIClient(cfg, apiKey, account, 30*time.Second)
+	return NewClient(TestnetConfig(), WithStarkAccount(account))
+}
+
+// createMockClient returns a client pointed at a local server running handler
+func createMockClient(t *testing.T, handler http.HandlerFunc) *APIClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	account, err := createTestAccount()
+	require.NoError(t, err)
+
+	return NewAPIClient(EndpointConfig{APIBaseURL: server.URL}, TestAPIKey, account, 5*time.Second)
 }
 
 func TestAPIClient_GetMarkets_SingleValidMarket(t *testing.T) {
@@ -183,18 +196,13 @@ func TestAPIClient_SubmitOrder_ValidOrder(t *testing.T) {
 	expireTime := time.Now().Add(1 * time.Hour)
 
 	params := CreateOrderObjectParams{
-		Market:          market,
-		Account:         *account,
-		SyntheticAmount: decimal.NewFromFloat(0.001), // Small BTC amount
-		Price:           decimal.NewFromFloat(1),     // Place a low price so that it doesn't match
-		Side:            OrderSideBuy,
-		Signer:          account.Sign,
-		StarknetDomain: StarknetDomain{
-			Name:     "Perpetuals",
-			Version:  "v0",
-			ChainID:  "SN_SEPOLIA",
-			Revision: "1",
-		},
+		Market:                   market,
+		Account:                  *account,
+		SyntheticAmount:          decimal.NewFromFloat(0.001), // Small BTC amount
+		Price:                    decimal.NewFromFloat(1),     // Place a low price so that it doesn't match
+		Side:                     OrderSideBuy,
+		Signer:                   account.Sign,
+		StarknetDomain:           client.StarknetDomain(),
 		ExpireTime:               &expireTime,
 		PostOnly:                 false,
 		TimeInForce:              TimeInForceGTT,
@@ -217,3 +225,467 @@ func TestAPIClient_SubmitOrder_ValidOrder(t *testing.T) {
 
 	t.Logf("Successfully submitted order with ID: %s", response.Data.ExternalID)
 }
+
+func TestAPIClient_MarketFee_Cached(t *testing.T) {
+	calls := 0
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "/user/fees", r.URL.Path)
+		assert.Equal(t, "ETH-USD", r.URL.Query().Get("market"))
+		w.Write([]byte(`{"status":"OK","data":[{"market":"ETH-USD","makerFeeRate":"0.0001","takerFeeRate":"0.00025","builderFeeRate":"0"}]}`))
+	})
+	ctx := context.Background()
+
+	fee, err := client.MarketFee(ctx, "ETH-USD")
+	require.NoError(t, err)
+	assert.Equal(t, "0.0001", fee.MakerFeeRate.String())
+	assert.Equal(t, "0.00025", fee.TakerFeeRate.String())
+
+	_, err = client.MarketFee(ctx, "ETH-USD")
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls, "Second call should be served from the cache")
+
+	_, err = client.RefreshMarketFee(ctx, "ETH-USD")
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls, "Refresh should always hit the API")
+
+	client.SetFeeCacheTTL(0)
+	_, err = client.MarketFee(ctx, "ETH-USD")
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls, "Zero TTL should disable caching")
+}
+
+func TestAPIClient_CancelOrder(t *testing.T) {
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, TestAPIKey, r.Header.Get("X-API-Key"))
+		switch {
+		case r.URL.Path == "/user/order/42":
+			w.Write([]byte(`{"status":"OK"}`))
+		case r.URL.Path == "/user/order" && r.URL.Query().Get("externalId") == "my-order":
+			w.Write([]byte(`{"status":"OK"}`))
+		default:
+			w.Write([]byte(`{"status":"ERROR","error":{"code":1142,"message":"Order not found"}}`))
+		}
+	})
+	ctx := context.Background()
+
+	_, err := client.CancelOrderByID(ctx, 42)
+	require.NoError(t, err)
+
+	_, err = client.CancelOrderByExternalID(ctx, "my-order")
+	require.NoError(t, err)
+
+	_, err = client.CancelOrderByExternalID(ctx, "unknown")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1142", "Exchange error code should be surfaced")
+}
+
+func TestAPIClient_MassCancel(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		bodies []map[string]interface{}
+	)
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user/orders":
+			assert.Equal(t, "SELL", r.URL.Query().Get("side"))
+			if markets := r.URL.Query()["market"]; len(markets) > 0 {
+				assert.Equal(t, []string{"BTC-USD"}, markets)
+				w.Write([]byte(`{"status":"OK","data":[{"id":7,"market":"BTC-USD"},{"id":9,"market":"BTC-USD"}]}`))
+				return
+			}
+			w.Write([]byte(`{"status":"OK","data":[{"id":7,"market":"BTC-USD"},{"id":9,"market":"BTC-USD"},` +
+				`{"id":15,"market":"ETH-USD"},{"id":21,"externalId":"ext-21","market":"SOL-USD"}]}`))
+		case "/user/order/massCancel":
+			assert.Equal(t, http.MethodPost, r.Method)
+			var body map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			mu.Lock()
+			bodies = append(bodies, body)
+			mu.Unlock()
+			w.Write([]byte(`{"status":"OK"}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+	ctx := context.Background()
+
+	_, err := client.MassCancel(ctx, MassCancelParams{Markets: []string{"BTC-USD", "ETH-USD"}})
+	require.NoError(t, err)
+
+	side := OrderSideSell
+	_, err = client.MassCancel(ctx, MassCancelParams{Markets: []string{"BTC-USD"}, Side: &side})
+	require.NoError(t, err)
+
+	// Explicit orders not on the side are left alone
+	_, err = client.MassCancel(ctx, MassCancelParams{
+		Markets:          []string{"ETH-USD"},
+		OrderIDs:         []uint{9, 12},
+		ExternalOrderIDs: []string{"ext-21"},
+		Side:             &side,
+	})
+	require.NoError(t, err)
+
+	_, err = client.MassCancel(ctx, MassCancelParams{CancelAll: true, Side: &side})
+	require.NoError(t, err)
+
+	_, err = client.CancelAllOrders(ctx)
+	require.NoError(t, err)
+
+	_, err = client.MassCancel(ctx, MassCancelParams{OrderIDs: []uint{9}, Side: &side})
+	require.Error(t, err, "Mass cancel by side without markets or cancel all should be rejected")
+
+	_, err = client.MassCancel(ctx, MassCancelParams{})
+	require.Error(t, err, "Mass cancel without filters should be rejected")
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, bodies, 5)
+	ids := func(ids ...float64) map[string]interface{} {
+		values := make([]interface{}, len(ids))
+		for i, id := range ids {
+			values[i] = id
+		}
+		return map[string]interface{}{"orderIds": values}
+	}
+	assert.Equal(t, map[string]interface{}{"markets": []interface{}{"BTC-USD", "ETH-USD"}}, bodies[0])
+	assert.Equal(t, ids(7, 9), bodies[1])
+	assert.Equal(t, ids(9, 15, 21), bodies[2])
+	assert.Equal(t, ids(7, 9, 15, 21), bodies[3])
+	assert.Equal(t, map[string]interface{}{"cancelAll": true}, bodies[4])
+}
+
+const testOrderJSON = `{"id":7,"accountId":3,"externalId":"ext-7","market":"BTC-USD","type":"LIMIT","side":"BUY",` +
+	`"status":"PARTIALLY_FILLED","price":"43000","averagePrice":"42999.5","qty":"0.01","filledQty":"0.004",` +
+	`"payedFee":"0.08","reduceOnly":false,"postOnly":true,"timeInForce":"GTT","createdTime":1704420537000,` +
+	`"updatedTime":1704420538000,"expireTime":1704424137000}`
+
+func TestAPIClient_GetOpenOrders(t *testing.T) {
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/user/orders", r.URL.Path)
+		assert.Equal(t, []string{"BTC-USD", "ETH-USD"}, r.URL.Query()["market"])
+		assert.Equal(t, "LIMIT", r.URL.Query().Get("type"))
+		assert.Equal(t, "BUY", r.URL.Query().Get("side"))
+		w.Write([]byte(`{"status":"OK","data":[` + testOrderJSON + `]}`))
+	})
+
+	orderType := OrderTypeLimit
+	side := OrderSideBuy
+	orders, err := client.GetOpenOrders(context.Background(), OpenOrdersParams{
+		Markets: []string{"BTC-USD", "ETH-USD"},
+		Type:    &orderType,
+		Side:    &side,
+	})
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+
+	order := orders[0]
+	assert.Equal(t, uint(7), order.ID)
+	assert.Equal(t, "ext-7", order.ExternalID)
+	assert.Equal(t, OrderStatusPartiallyFilled, order.Status)
+	assert.Equal(t, TimeInForceGTT, order.TimeInForce)
+	assert.True(t, order.FilledQty.Equal(decimal.RequireFromString("0.004")))
+	assert.True(t, order.AveragePrice.Equal(decimal.RequireFromString("42999.5")))
+}
+
+func TestAPIClient_GetOrdersHistory_Pagination(t *testing.T) {
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/user/orders/history", r.URL.Path)
+		assert.Equal(t, "50", r.URL.Query().Get("limit"))
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"status":"OK","data":[` + testOrderJSON + `],"pagination":{"cursor":7,"count":1}}`))
+			return
+		}
+		assert.Equal(t, "7", r.URL.Query().Get("cursor"))
+		w.Write([]byte(`{"status":"OK","data":[],"pagination":{"cursor":null,"count":0}}`))
+	})
+	ctx := context.Background()
+
+	orders, pagination, err := client.GetOrdersHistory(ctx, OrdersHistoryParams{Limit: 50})
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	require.NotNil(t, pagination.Cursor)
+	assert.Equal(t, int64(7), *pagination.Cursor)
+
+	orders, pagination, err = client.GetOrdersHistory(ctx, OrdersHistoryParams{Limit: 50, Cursor: pagination.Cursor})
+	require.NoError(t, err)
+	assert.Empty(t, orders)
+	assert.Nil(t, pagination.Cursor, "Last page should have no cursor")
+}
+
+func TestAPIClient_GetOrderByIDs(t *testing.T) {
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user/orders/7":
+			w.Write([]byte(`{"status":"OK","data":` + testOrderJSON + `}`))
+		case "/user/orders/external/ext-7":
+			w.Write([]byte(`{"status":"OK","data":[` + testOrderJSON + `]}`))
+		default:
+			w.Write([]byte(`{"status":"OK","data":[]}`))
+		}
+	})
+	ctx := context.Background()
+
+	order, err := client.GetOrderByID(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "ext-7", order.ExternalID)
+
+	order, err = client.GetOrderByExternalID(ctx, "ext-7")
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), order.ID)
+
+	_, err = client.GetOrderByExternalID(ctx, "missing")
+	require.Error(t, err)
+}
+
+func TestAPIClient_GetPositions(t *testing.T) {
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user/positions":
+			assert.Equal(t, "BTC-USD", r.URL.Query().Get("market"))
+			assert.Equal(t, "LONG", r.URL.Query().Get("side"))
+			w.Write([]byte(`{"status":"OK","data":[{"id":1,"accountId":3,"market":"BTC-USD","side":"LONG",` +
+				`"leverage":"10","size":"0.5","value":"21500","openPrice":"42000","markPrice":"43000",` +
+				`"liquidationPrice":"38000","margin":"2150","unrealisedPnl":"500","realisedPnl":"-1.2",` +
+				`"adl":2,"createdAt":1704420537000,"updatedAt":1704420538000}]}`))
+		case "/user/positions/history":
+			assert.Equal(t, "10", r.URL.Query().Get("limit"))
+			w.Write([]byte(`{"status":"OK","data":[{"id":2,"market":"ETH-USD","side":"SHORT","leverage":"5",` +
+				`"size":"1","openPrice":"2500","exitType":"TRADE","exitPrice":"2400","realisedPnl":"100",` +
+				`"createdTime":1704420537000,"closedTime":1704420538000}],"pagination":{"cursor":2,"count":1}}`))
+		}
+	})
+	ctx := context.Background()
+
+	side := PositionSideLong
+	positions, err := client.GetPositions(ctx, []string{"BTC-USD"}, &side)
+	require.NoError(t, err)
+	require.Len(t, positions, 1)
+	assert.Equal(t, PositionSideLong, positions[0].Side)
+	assert.True(t, positions[0].LiquidationPrice.Equal(decimal.NewFromInt(38000)))
+	assert.True(t, positions[0].UnrealisedPnl.Equal(decimal.NewFromInt(500)))
+	assert.True(t, positions[0].RealisedPnl.Equal(decimal.RequireFromString("-1.2")))
+
+	history, pagination, err := client.GetPositionsHistory(ctx, PositionsHistoryParams{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, PositionExitTypeTrade, history[0].ExitType)
+	assert.Equal(t, int64(2), *pagination.Cursor)
+}
+
+func TestAPIClient_GetBalance(t *testing.T) {
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/user/balance", r.URL.Path)
+		w.Write([]byte(`{"status":"OK","data":{"collateralName":"USD","balance":"1000","equity":"1050.5",` +
+			`"availableForTrade":"800","availableForWithdrawal":"750","unrealisedPnl":"50.5",` +
+			`"initialMargin":"250.5","maintenanceMargin":"125","marginRatio":"0.119","updatedTime":1704420537000}}`))
+	})
+
+	balance, err := client.GetBalance(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "USD", balance.CollateralName)
+	assert.True(t, balance.Equity.Equal(decimal.RequireFromString("1050.5")))
+	assert.True(t, balance.AvailableForTrade.Equal(decimal.NewFromInt(800)))
+	assert.True(t, balance.AvailableForWithdrawal.Equal(decimal.NewFromInt(750)))
+	assert.True(t, balance.InitialMargin.Equal(decimal.RequireFromString("250.5")))
+	assert.True(t, balance.MaintenanceMargin.Equal(decimal.NewFromInt(125)))
+	assert.True(t, balance.MarginRatio.Equal(decimal.RequireFromString("0.119")))
+}
+
+func TestAPIClient_GetTrades_Iterator(t *testing.T) {
+	var pages atomic.Int32
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		pages.Add(1)
+		assert.Equal(t, "/user/trades", r.URL.Path)
+		assert.Equal(t, "LIQUIDATION", r.URL.Query().Get("type"))
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			w.Write([]byte(`{"status":"OK","data":[` +
+				`{"id":5,"orderId":50,"market":"BTC-USD","side":"BUY","price":"43000","qty":"0.1","fee":"2.15","isTaker":true,"tradeType":"LIQUIDATION","createdTime":5000},` +
+				`{"id":4,"orderId":40,"market":"BTC-USD","side":"SELL","price":"42000","qty":"0.1","fee":"0.84","isTaker":false,"tradeType":"LIQUIDATION","createdTime":4000}` +
+				`],"pagination":{"cursor":4,"count":2}}`))
+		case "4":
+			w.Write([]byte(`{"status":"OK","data":[` +
+				`{"id":3,"orderId":30,"market":"BTC-USD","side":"BUY","price":"41000","qty":"0.1","fee":"2.05","isTaker":true,"tradeType":"LIQUIDATION","createdTime":3000},` +
+				`{"id":2,"orderId":20,"market":"BTC-USD","side":"BUY","price":"40000","qty":"0.1","fee":"2","isTaker":true,"tradeType":"LIQUIDATION","createdTime":2000}` +
+				`],"pagination":{"cursor":2,"count":2}}`))
+		default:
+			// The page after cursor 2 only holds trades before Start
+			t.Errorf("unexpected cursor %s", r.URL.Query().Get("cursor"))
+		}
+	})
+
+	tradeType := TradeTypeLiquidation
+	var ids []uint
+	for trade, err := range client.GetTrades(context.Background(), TradesParams{
+		Type:  &tradeType,
+		Start: time.UnixMilli(2500),
+		End:   time.UnixMilli(5000),
+	}) {
+		require.NoError(t, err)
+		ids = append(ids, trade.ID)
+	}
+
+	assert.Equal(t, []uint{4, 3}, ids, "Trades outside the time range should be skipped")
+	assert.Equal(t, int32(2), pages.Load(), "Pages older than Start should not be fetched")
+
+	for trade, err := range client.GetTrades(context.Background(), TradesParams{Type: &tradeType}) {
+		require.NoError(t, err)
+		assert.True(t, trade.IsTaker)
+		assert.Equal(t, uint(50), trade.OrderID)
+		break
+	}
+}
+
+func TestAPIClient_GetFundingPayments(t *testing.T) {
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/user/funding/history", r.URL.Path)
+		assert.Equal(t, "BTC-USD", r.URL.Query().Get("market"))
+		assert.Equal(t, "1704067200000", r.URL.Query().Get("fromTime"))
+		assert.Equal(t, "1704153600000", r.URL.Query().Get("toTime"))
+		w.Write([]byte(`{"status":"OK","data":[{"id":1,"market":"BTC-USD","positionId":9,"side":"LONG",` +
+			`"size":"0.5","value":"21500","markPrice":"43000","fundingFee":"-0.215","fundingRate":"0.00001",` +
+			`"paidTime":1704070800000}],"pagination":{"cursor":null,"count":1}}`))
+	})
+	ctx := context.Background()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	payments, pagination, err := client.GetFundingPayments(ctx, FundingPaymentsParams{
+		Markets: []string{"BTC-USD"},
+		Start:   start,
+		End:     start.Add(24 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, payments, 1)
+	assert.True(t, payments[0].FundingRate.Equal(decimal.RequireFromString("0.00001")))
+	assert.True(t, payments[0].Size.Equal(decimal.RequireFromString("0.5")))
+	assert.True(t, payments[0].FundingFee.Equal(decimal.RequireFromString("-0.215")))
+	assert.Nil(t, pagination.Cursor)
+
+	_, _, err = client.GetFundingPayments(ctx, FundingPaymentsParams{})
+	require.Error(t, err, "Missing start time should be rejected")
+}
+
+func TestAPIClient_Leverage(t *testing.T) {
+	var updates atomic.Int32
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/info/markets" {
+			assert.Equal(t, "BTC-USD", r.URL.Query().Get("market"))
+			w.Write([]byte(`{"status":"OK","data":[` + testMarketJSON + `]}`))
+			return
+		}
+		assert.Equal(t, "/user/leverage", r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, []string{"BTC-USD", "ETH-USD"}, r.URL.Query()["market"])
+			w.Write([]byte(`{"status":"OK","data":[{"market":"BTC-USD","leverage":"10"},{"market":"ETH-USD","leverage":"5"}]}`))
+		case http.MethodPatch:
+			updates.Add(1)
+			var body map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]interface{}{"market": "BTC-USD", "leverage": "20"}, body)
+			w.Write([]byte(`{"status":"OK","data":{"market":"BTC-USD","leverage":"20"}}`))
+		}
+	})
+	ctx := context.Background()
+
+	leverage, err := client.GetLeverage(ctx, []string{"BTC-USD", "ETH-USD"})
+	require.NoError(t, err)
+	require.Len(t, leverage, 2)
+	assert.True(t, leverage[1].Leverage.Equal(decimal.NewFromInt(5)))
+
+	updated, err := client.UpdateLeverage(ctx, "BTC-USD", decimal.NewFromInt(20))
+	require.NoError(t, err)
+	assert.True(t, updated.Leverage.Equal(decimal.NewFromInt(20)))
+
+	_, err = client.UpdateLeverage(ctx, "BTC-USD", decimal.Zero)
+	require.Error(t, err, "Non-positive leverage should be rejected")
+
+	_, err = client.UpdateLeverage(ctx, "BTC-USD", decimal.NewFromInt(51))
+	require.Error(t, err, "Leverage above the market maximum should be rejected")
+	assert.Equal(t, int32(1), updates.Load(), "Rejected leverage should not be sent")
+}
+
+func TestAPIClient_GetMarketStats(t *testing.T) {
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/info/markets/BTC-USD/stats", r.URL.Path)
+		w.Write([]byte(`{"status":"OK","data":{"dailyVolume":"1250000.5","dailyPriceChangePercentage":"0.021",` +
+			`"lastPrice":"43000","markPrice":"43001.2","indexPrice":"42998.7","fundingRate":"0.000013",` +
+			`"nextFundingRate":1704423600000,"openInterest":"25000000","openInterestBase":"581.4"}}`))
+	})
+
+	stats, err := client.GetMarketStats(context.Background(), "BTC-USD")
+	require.NoError(t, err)
+	assert.Equal(t, "43001.2", stats.MarkPrice.String())
+	assert.Equal(t, "42998.7", stats.IndexPrice.String())
+	assert.Equal(t, "25000000", stats.OpenInterest.String())
+	assert.Equal(t, "0.000013", stats.FundingRate.String())
+	assert.Equal(t, int64(1704423600000), stats.NextFundingTime().UnixMilli())
+}
+
+func TestAPIClient_GetOrderbook(t *testing.T) {
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/info/markets/BTC-USD/orderbook", r.URL.Path)
+		w.Write([]byte(`{"status":"OK","data":{"market":"BTC-USD",` +
+			`"bid":[{"qty":"0.5","price":"42999"},{"qty":"1.2","price":"42998"}],` +
+			`"ask":[{"qty":"0.3","price":"43001"}]}}`))
+	})
+
+	book, err := client.GetOrderbook(context.Background(), "BTC-USD")
+	require.NoError(t, err)
+	assert.Equal(t, "BTC-USD", book.Market)
+	assert.Len(t, book.Bid, 2)
+
+	mid, ok := book.Mid()
+	require.True(t, ok)
+	assert.Equal(t, "43000", mid.String())
+}
+
+func TestAPIClient_PublicMarketData(t *testing.T) {
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/info/markets/BTC-USD/trades":
+			w.Write([]byte(`{"status":"OK","data":[{"i":1844000421446684673,"m":"BTC-USD","S":"SELL","tT":"TRADE","T":1728478575527,"p":"62324","q":"0.00001"}]}`))
+		case "/info/candles/BTC-USD/mark-prices":
+			assert.Equal(t, "PT1H", r.URL.Query().Get("interval"))
+			assert.Equal(t, "2", r.URL.Query().Get("limit"))
+			assert.Equal(t, "1704070800000", r.URL.Query().Get("endTime"))
+			w.Write([]byte(`{"status":"OK","data":[{"o":"43000","h":"43500","l":"42900","c":"43100","T":1704070800000},` +
+				`{"o":"42800","h":"43050","l":"42700","c":"43000","T":1704067200000}]}`))
+		case "/info/BTC-USD/funding":
+			assert.Equal(t, "1704067200000", r.URL.Query().Get("startTime"))
+			assert.Equal(t, "1704070800000", r.URL.Query().Get("endTime"))
+			w.Write([]byte(`{"status":"OK","data":[{"m":"BTC-USD","T":1704070800000,"f":"0.000013"}],"pagination":{"cursor":null,"count":1}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	trades, err := client.GetPublicTrades(ctx, "BTC-USD")
+	require.NoError(t, err)
+	require.Len(t, trades, 1)
+	assert.Equal(t, OrderSideSell, trades[0].Side)
+	assert.Equal(t, int64(1844000421446684673), trades[0].ID)
+	assert.Equal(t, "62324", trades[0].Price.String())
+
+	candles, err := client.GetCandles(ctx, "BTC-USD", CandlesParams{
+		Type:     CandleTypeMarkPrices,
+		Interval: CandleInterval1Hour,
+		Limit:    2,
+		End:      start.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, candles, 2)
+	assert.Equal(t, "43500", candles[0].High.String())
+
+	_, err = client.GetCandles(ctx, "BTC-USD", CandlesParams{})
+	require.Error(t, err, "Missing interval should be rejected")
+
+	rates, _, err := client.GetFundingRates(ctx, "BTC-USD", FundingRatesParams{Start: start, End: start.Add(time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, rates, 1)
+	assert.Equal(t, "0.000013", rates[0].FundingRate.String())
+}
@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -12,8 +13,16 @@ import (
 	"time"
 )
 
+// EndpointConfig describes an exchange environment: where to send requests and which
+// Starknet domain orders must be signed for. Use MainnetConfig or TestnetConfig rather than
+// assembling it by hand, as a domain that does not match the endpoints produces signatures
+// the exchange rejects.
 type EndpointConfig struct {
-	APIBaseURL string
+	APIBaseURL     string
+	StreamURL      string
+	OnboardingURL  string
+	SigningDomain  string
+	StarknetDomain StarknetDomain
 }
 
 var (
@@ -28,10 +37,12 @@ type BaseModule struct {
 	starkAccount   *StarkPerpetualAccount
 	httpClient     *http.Client
 	clientTimeout  time.Duration
+	retryPolicy    RetryPolicy
+	rateLimiter    *rateLimiter
 }
 
 // NewBaseModule constructs a BaseModule with all fields explicitly provided.
-// Pass nil for httpClient to allow lazy creation. Pass nil for starkAccount if intentionally absent.
+// Pass nil for httpClient to use a default client with clientTimeout. Pass nil for starkAccount if intentionally absent.
 func NewBaseModule(
 	cfg EndpointConfig,
 	apiKey string,
@@ -39,15 +50,32 @@ func NewBaseModule(
 	httpClient *http.Client,
 	clientTimeout time.Duration,
 ) *BaseModule {
+	// The client is created up front so that concurrent requests never race to create it
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: clientTimeout}
+	}
 	return &BaseModule{
 		endpointConfig: cfg,
 		apiKey:         apiKey,
 		starkAccount:   starkAccount,
 		httpClient:     httpClient,
 		clientTimeout:  clientTimeout,
+		retryPolicy:    DefaultRetryPolicy,
+		rateLimiter:    newRateLimiter(DefaultMarketDataRateLimit, DefaultTradingRateLimit),
 	}
 }
 
+// SetRetryPolicy changes how failed requests are retried. Use NoRetryPolicy to disable retries.
+func (m *BaseModule) SetRetryPolicy(policy RetryPolicy) {
+	m.retryPolicy = policy
+}
+
+// SetRateLimits replaces the client-side rate limits of public market data (/info) endpoints and
+// of all other endpoints. A zero RateLimit only applies the limits reported by the exchange.
+func (m *BaseModule) SetRateLimits(marketData, trading RateLimit) {
+	m.rateLimiter = newRateLimiter(marketData, trading)
+}
+
 func (m *BaseModule) EndpointConfig() EndpointConfig {
 	return m.endpointConfig
 }
@@ -67,20 +95,13 @@ func (m *BaseModule) StarkAccount() (*StarkPerpetualAccount, error) {
 }
 
 func (m *BaseModule) HTTPClient() *http.Client {
-	if m.httpClient == nil {
-		m.httpClient = &http.Client{
-			Timeout: m.clientTimeout,
-		}
-	}
 	return m.httpClient
 }
 
 // Close analogous to closing aiohttp session.
+// The client is kept for further requests; only its idle connections are closed.
 func (m *BaseModule) Close() {
-	if m.httpClient != nil {
-		m.httpClient.CloseIdleConnections()
-		m.httpClient = nil
-	}
+	m.httpClient.CloseIdleConnections()
 }
 
 // GetURL builds a full URL with optional query params.
@@ -100,17 +121,102 @@ func (m *BaseModule) GetURL(path string, query map[string]string) (string, error
 	return u.String(), nil
 }
 
+// GetURLWithQuery builds a full URL from query values, allowing repeated parameters
+// such as market=BTC-USD&market=ETH-USD.
+func (m *BaseModule) GetURLWithQuery(path string, query url.Values) (string, error) {
+	u, err := url.Parse(m.endpointConfig.APIBaseURL + path)
+	if err != nil {
+		return "", err
+	}
+	if len(query) > 0 {
+		u.RawQuery = query.Encode()
+	}
+	return u.String(), nil
+}
+
 // DoRequest performs an HTTP request and unmarshals the JSON response into the provided object
 // This function deduplicates common HTTP request logic across the SDK
+// Every attempt waits for the rate limiter, and failed attempts are repeated according to the
+// retry policy when the request is idempotent.
 func (m *BaseModule) DoRequest(ctx context.Context, method, url string, body io.Reader, result interface{}) error {
+	_, err := m.doRequest(ctx, method, url, body, result)
+	return err
+}
+
+// doRequest is DoRequest, also returning the number of attempts that were sent
+func (m *BaseModule) doRequest(ctx context.Context, method, url string, body io.Reader, result interface{}) (int, error) {
+	// Buffer the body so that it can be sent again on retries
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return 0, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	policy := m.retryPolicy
+	retryable := isIdempotentRequest(ctx, method)
+	bucket := m.rateLimiter.bucketFor(url)
+
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		if err := bucket.wait(ctx); err != nil {
+			// The failure that led to the retry says more than the limiter does
+			if lastErr != nil {
+				return attempt - 1, lastErr
+			}
+			return attempt - 1, err
+		}
+		statusCode, err := m.doAttempt(ctx, method, url, payload, body != nil, result)
+		lastErr = err
+
+		retry := err != nil && retryable && attempt < policy.MaxAttempts &&
+			ctx.Err() == nil && policy.shouldRetry(statusCode, err)
+		var delay time.Duration
+		if retry {
+			delay = jitteredBackoff(policy.InitialBackoff, policy.MaxBackoff, policy.Multiplier, attempt)
+		}
+
+		if policy.OnAttempt != nil {
+			policy.OnAttempt(RequestAttempt{
+				Method:     method,
+				URL:        url,
+				Attempt:    attempt,
+				StatusCode: statusCode,
+				Err:        err,
+				Retry:      retry,
+				Delay:      delay,
+			})
+		}
+		if !retry {
+			return attempt, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, err
+		case <-timer.C:
+		}
+	}
+}
+
+// doAttempt performs a single HTTP request, returning the response status code if one was received
+func (m *BaseModule) doAttempt(ctx context.Context, method, url string, payload []byte, hasBody bool, result interface{}) (int, error) {
+	var body io.Reader
+	if hasBody {
+		body = bytes.NewReader(payload)
+	}
+
 	// Create HTTP request
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return 0, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Only set Content-Type if we have a request body
-	if body != nil {
+	if hasBody {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
@@ -123,27 +229,32 @@ func (m *BaseModule) DoRequest(ctx context.Context, method, url string, body io.
 	client := m.HTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return 0, &networkError{fmt.Errorf("failed to execute request: %w", err)}
 	}
 	defer resp.Body.Close()
+	m.rateLimiter.bucketFor(url).observe(resp, time.Now())
 
 	// Read response body
 	responseBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return resp.StatusCode, &networkError{fmt.Errorf("failed to read response body: %w", err)}
 	}
 
-	// Check for HTTP errors
+	// Check for HTTP errors and error statuses reported in the body
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(responseBody))
+		return resp.StatusCode, newAPIError(resp, responseBody)
+	}
+	var envelope errorEnvelope
+	if err := json.Unmarshal(responseBody, &envelope); err == nil && envelope.Status != "" && envelope.Status != "OK" {
+		return resp.StatusCode, newAPIError(resp, responseBody)
 	}
 
 	// Parse JSON response into the provided result object
 	if err := json.Unmarshal(responseBody, result); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
+		return resp.StatusCode, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	return nil
+	return resp.StatusCode, nil
 }
 
 type StarkPerpetualAccount struct {
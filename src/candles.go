@@ -0,0 +1,47 @@
+package sdk
+
+import "github.com/shopspring/decimal"
+
+// CandleType selects the price series candles are built from
+type CandleType string
+
+const (
+	CandleTypeTrades      CandleType = "trades"
+	CandleTypeMarkPrices  CandleType = "mark-prices"
+	CandleTypeIndexPrices CandleType = "index-prices"
+)
+
+// CandleInterval is an ISO 8601 duration supported by the candles endpoint
+type CandleInterval string
+
+const (
+	CandleInterval1Minute   CandleInterval = "PT1M"
+	CandleInterval5Minutes  CandleInterval = "PT5M"
+	CandleInterval15Minutes CandleInterval = "PT15M"
+	CandleInterval30Minutes CandleInterval = "PT30M"
+	CandleInterval1Hour     CandleInterval = "PT1H"
+	CandleInterval2Hours    CandleInterval = "PT2H"
+	CandleInterval4Hours    CandleInterval = "PT4H"
+	CandleInterval1Day      CandleInterval = "P1D"
+)
+
+// CandleModel is an OHLCV candle. Volume is only reported for trade candles.
+type CandleModel struct {
+	Open      decimal.Decimal `json:"o"`
+	High      decimal.Decimal `json:"h"`
+	Low       decimal.Decimal `json:"l"`
+	Close     decimal.Decimal `json:"c"`
+	Volume    decimal.Decimal `json:"v"`
+	Timestamp int64           `json:"T"`
+}
+
+// CandleUpdate carries the latest state of the candles of a market received from the candles stream.
+// The last candle is the one still being formed.
+type CandleUpdate struct {
+	Market    string
+	Type      CandleType
+	Interval  CandleInterval
+	Candles   []CandleModel
+	Seq       int64
+	Timestamp int64
+}
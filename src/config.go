@@ -9,6 +9,38 @@ type StarknetDomain struct {
 	Revision string `json:"revision"`
 }
 
+// MainnetConfig returns the endpoints and signing domain of Extended on Starknet mainnet
+func MainnetConfig() EndpointConfig {
+	return EndpointConfig{
+		APIBaseURL:    "https://api.starknet.extended.exchange/api/v1",
+		StreamURL:     "wss://api.starknet.extended.exchange/stream.extended.exchange/v1",
+		OnboardingURL: "https://api.starknet.extended.exchange",
+		SigningDomain: "extended.exchange",
+		StarknetDomain: StarknetDomain{
+			Name:     "Perpetuals",
+			Version:  "v0",
+			ChainID:  "SN_MAIN",
+			Revision: "1",
+		},
+	}
+}
+
+// TestnetConfig returns the endpoints and signing domain of Extended on Starknet Sepolia
+func TestnetConfig() EndpointConfig {
+	return EndpointConfig{
+		APIBaseURL:    "https://api.starknet.sepolia.extended.exchange/api/v1",
+		StreamURL:     "wss://api.starknet.sepolia.extended.exchange/stream.extended.exchange/v1",
+		OnboardingURL: "https://api.starknet.sepolia.extended.exchange",
+		SigningDomain: "starknet.sepolia.extended.exchange",
+		StarknetDomain: StarknetDomain{
+			Name:     "Perpetuals",
+			Version:  "v0",
+			ChainID:  "SN_SEPOLIA",
+			Revision: "1",
+		},
+	}
+}
+
 // TradingFeeModel represents trading fees for a market
 type TradingFeeModel struct {
 	Market         string          `json:"market"`
@@ -0,0 +1,136 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Sentinel errors matched by *APIError with errors.Is
+var (
+	ErrUnauthorized       = errors.New("unauthorized")
+	ErrNotFound           = errors.New("not found")
+	ErrOrderNotFound      = errors.New("order not found")
+	ErrRateLimited        = errors.New("rate limited")
+	ErrInsufficientMargin = errors.New("insufficient margin")
+	ErrInvalidSignature   = errors.New("invalid signature")
+	ErrServerError        = errors.New("exchange server error")
+)
+
+// Exchange error codes with a sentinel error
+const (
+	errorCodeInvalidSignature      = 1101
+	errorCodeOrderCostExceedsFunds = 1140
+	errorCodeOrderNotFound         = 1142
+	errorCodeOpenLossExceedsEquity = 1147
+)
+
+var apiErrorCodes = map[int]error{
+	errorCodeInvalidSignature:      ErrInvalidSignature,
+	errorCodeOrderCostExceedsFunds: ErrInsufficientMargin,
+	errorCodeOrderNotFound:         ErrOrderNotFound,
+	errorCodeOpenLossExceedsEquity: ErrInsufficientMargin,
+}
+
+// requestIDHeader is the response header carrying the exchange's request ID
+const requestIDHeader = "X-Request-Id"
+
+// APIError is returned when the exchange rejects a request, either with a non-200 HTTP status
+// or with a non-OK status in the response body. Use errors.Is with the sentinel errors above
+// to react to specific failures.
+type APIError struct {
+	HTTPStatus int
+	Status     string
+	Code       int
+	Message    string
+	RequestID  string
+	// RetryAfter is how long the exchange asked to wait before retrying, if it said so
+	RetryAfter time.Duration
+	// URL is the endpoint the failed request was sent to
+	URL string
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "API request failed with status %d", e.HTTPStatus)
+	if e.Status != "" {
+		fmt.Fprintf(&b, " (%s)", e.Status)
+	}
+	if e.Code != 0 {
+		fmt.Fprintf(&b, ": code %d", e.Code)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " [request id %s]", e.RequestID)
+	}
+	return b.String()
+}
+
+// Is matches the sentinel errors by exchange error code and HTTP status
+func (e *APIError) Is(target error) bool {
+	if sentinel, ok := apiErrorCodes[e.Code]; ok && sentinel == target {
+		return true
+	}
+
+	switch target {
+	case ErrUnauthorized:
+		return e.HTTPStatus == http.StatusUnauthorized || e.HTTPStatus == http.StatusForbidden
+	case ErrNotFound:
+		return e.HTTPStatus == http.StatusNotFound
+	case ErrOrderNotFound:
+		return e.HTTPStatus == http.StatusNotFound && strings.Contains(e.URL, "/user/order")
+	case ErrRateLimited:
+		return e.HTTPStatus == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.HTTPStatus >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// Temporary reports whether the failure is transient and the request may succeed if repeated
+func (e *APIError) Temporary() bool {
+	return e.HTTPStatus == http.StatusTooManyRequests || e.HTTPStatus >= http.StatusInternalServerError
+}
+
+// ErrorDetail is the error payload returned by the exchange alongside a non-OK status
+type ErrorDetail struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// errorEnvelope is the part of every response body that reports failures
+type errorEnvelope struct {
+	Status string       `json:"status"`
+	Error  *ErrorDetail `json:"error,omitempty"`
+}
+
+// newAPIError builds the error for a response that failed with a non-200 status or a non-OK body status
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		HTTPStatus: resp.StatusCode,
+		RequestID:  resp.Header.Get(requestIDHeader),
+	}
+	if retryAfter, ok := parseRetryAfter(resp.Header.Get(retryAfterHeader), time.Now()); ok {
+		apiErr.RetryAfter = retryAfter
+	}
+	if resp.Request != nil {
+		apiErr.URL = resp.Request.URL.String()
+	}
+
+	var envelope errorEnvelope
+	if err := json.Unmarshal(body, &envelope); err == nil && (envelope.Status != "" || envelope.Error != nil) {
+		apiErr.Status = envelope.Status
+		if envelope.Error != nil {
+			apiErr.Code = envelope.Error.Code
+			apiErr.Message = envelope.Error.Message
+		}
+	} else {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+	return apiErr
+}
@@ -0,0 +1,74 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIError_FromHTTPStatus(t *testing.T) {
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		switch r.URL.Path {
+		case "/user/balance":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status":"ERROR","error":{"code":1140,"message":"Order cost exceeds available balance"}}`))
+		case "/user/orders/42":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status":"ERROR","error":{"code":0,"message":"Not found"}}`))
+		case "/user/positions":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/user/leverage":
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("upstream unavailable"))
+		}
+	})
+	client.SetRetryPolicy(NoRetryPolicy)
+	ctx := context.Background()
+
+	_, err := client.GetBalance(ctx)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.HTTPStatus)
+	assert.Equal(t, 1140, apiErr.Code)
+	assert.Equal(t, "req-123", apiErr.RequestID)
+	assert.ErrorIs(t, err, ErrInsufficientMargin)
+	assert.NotErrorIs(t, err, ErrRateLimited)
+	assert.False(t, apiErr.Temporary())
+	assert.Contains(t, err.Error(), "Order cost exceeds available balance")
+
+	_, err = client.GetOrderByID(ctx, 42)
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = client.GetPositions(ctx, nil, nil)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	require.ErrorAs(t, err, &apiErr)
+	assert.True(t, apiErr.Temporary())
+
+	_, err = client.GetLeverage(ctx, nil)
+	assert.ErrorIs(t, err, ErrServerError)
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "upstream unavailable", apiErr.Message)
+}
+
+func TestAPIError_FromBodyStatus(t *testing.T) {
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-456")
+		w.Write([]byte(`{"status":"ERROR","error":{"code":1101,"message":"Invalid signature"}}`))
+	})
+
+	_, err := client.UpdateLeverage(context.Background(), "BTC-USD", decimal.NewFromInt(5))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusOK, apiErr.HTTPStatus)
+	assert.Equal(t, "ERROR", apiErr.Status)
+	assert.Equal(t, "req-456", apiErr.RequestID)
+}
@@ -0,0 +1,35 @@
+package sdk
+
+import "github.com/shopspring/decimal"
+
+// FundingPaymentModel represents a funding payment applied to one of the account's positions.
+// A negative FundingFee means the account paid funding.
+type FundingPaymentModel struct {
+	ID          uint            `json:"id"`
+	AccountID   uint            `json:"accountId"`
+	Market      string          `json:"market"`
+	PositionID  uint            `json:"positionId"`
+	Side        PositionSide    `json:"side"`
+	Size        decimal.Decimal `json:"size"`
+	Value       decimal.Decimal `json:"value"`
+	MarkPrice   decimal.Decimal `json:"markPrice"`
+	FundingFee  decimal.Decimal `json:"fundingFee"`
+	FundingRate decimal.Decimal `json:"fundingRate"`
+	PaidTime    int64           `json:"paidTime"`
+}
+
+// FundingRateModel is a historical funding rate of a market
+type FundingRateModel struct {
+	Market      string          `json:"m"`
+	FundingRate decimal.Decimal `json:"f"`
+	Timestamp   int64           `json:"T"`
+}
+
+// FundingRateUpdate is a funding rate change received from the funding stream
+type FundingRateUpdate struct {
+	Market      string
+	FundingRate decimal.Decimal
+	// FundingTime is the epoch millis timestamp the rate applies to
+	FundingTime int64
+	Seq         int64
+}
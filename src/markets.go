@@ -1,5 +1,23 @@
 package sdk
 
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type MarketStatus string
+
+const (
+	MarketStatusActive     MarketStatus = "ACTIVE"
+	MarketStatusReduceOnly MarketStatus = "REDUCE_ONLY"
+	MarketStatusPrelisted  MarketStatus = "PRELISTED"
+	MarketStatusDelisted   MarketStatus = "DELISTED"
+	MarketStatusDisabled   MarketStatus = "DISABLED"
+)
+
 type L2ConfigModel struct {
 	Type                 string `json:"type"`
 	CollateralID         string `json:"collateralId"`
@@ -8,12 +26,210 @@ type L2ConfigModel struct {
 	SyntheticResolution  int64  `json:"syntheticResolution"`
 }
 
+// RiskFactorConfigModel is one margin tier: positions worth up to UpperBound
+// require RiskFactor of their value as margin
+type RiskFactorConfigModel struct {
+	UpperBound decimal.Decimal `json:"upperBound"`
+	RiskFactor decimal.Decimal `json:"riskFactor"`
+}
+
+// TradingConfigModel holds the order constraints of a market
+type TradingConfigModel struct {
+	MinOrderSize        decimal.Decimal         `json:"minOrderSize"`
+	MinOrderSizeChange  decimal.Decimal         `json:"minOrderSizeChange"`
+	MinPriceChange      decimal.Decimal         `json:"minPriceChange"`
+	MaxMarketOrderValue decimal.Decimal         `json:"maxMarketOrderValue"`
+	MaxLimitOrderValue  decimal.Decimal         `json:"maxLimitOrderValue"`
+	MaxPositionValue    decimal.Decimal         `json:"maxPositionValue"`
+	MaxLeverage         decimal.Decimal         `json:"maxLeverage"`
+	MaxNumOrders        decimal.Decimal         `json:"maxNumOrders"`
+	LimitPriceCap       decimal.Decimal         `json:"limitPriceCap"`
+	LimitPriceFloor     decimal.Decimal         `json:"limitPriceFloor"`
+	RiskFactorConfig    []RiskFactorConfigModel `json:"riskFactorConfig"`
+}
+
+// MarketStatsModel holds the 24h statistics and current prices of a market.
+// FundingRate is the predicted rate for the next funding payment, and despite its name
+// NextFundingRate is the epoch millis timestamp of that payment (see NextFundingTime).
+type MarketStatsModel struct {
+	DailyVolume                decimal.Decimal `json:"dailyVolume"`
+	DailyVolumeBase            decimal.Decimal `json:"dailyVolumeBase"`
+	DailyPriceChange           decimal.Decimal `json:"dailyPriceChange"`
+	DailyPriceChangePercentage decimal.Decimal `json:"dailyPriceChangePercentage"`
+	DailyLow                   decimal.Decimal `json:"dailyLow"`
+	DailyHigh                  decimal.Decimal `json:"dailyHigh"`
+	LastPrice                  decimal.Decimal `json:"lastPrice"`
+	AskPrice                   decimal.Decimal `json:"askPrice"`
+	BidPrice                   decimal.Decimal `json:"bidPrice"`
+	MarkPrice                  decimal.Decimal `json:"markPrice"`
+	IndexPrice                 decimal.Decimal `json:"indexPrice"`
+	FundingRate                decimal.Decimal `json:"fundingRate"`
+	NextFundingRate            int64           `json:"nextFundingRate"`
+	OpenInterest               decimal.Decimal `json:"openInterest"`
+	OpenInterestBase           decimal.Decimal `json:"openInterestBase"`
+}
+
+// NextFundingTime returns the time of the next funding payment
+func (s MarketStatsModel) NextFundingTime() time.Time {
+	return time.UnixMilli(s.NextFundingRate)
+}
+
 type MarketModel struct {
-	Name                     string        `json:"name"`
-	AssetName                string        `json:"assetName"`
-	AssetPrecision           int           `json:"assetPrecision"`
-	CollateralAssetName      string        `json:"collateralAssetName"`
-	CollateralAssetPrecision int           `json:"collateralAssetPrecision"`
-	Active                   bool          `json:"active"`
-	L2Config                 L2ConfigModel `json:"l2Config"`
+	Name                     string             `json:"name"`
+	AssetName                string             `json:"assetName"`
+	AssetPrecision           int                `json:"assetPrecision"`
+	CollateralAssetName      string             `json:"collateralAssetName"`
+	CollateralAssetPrecision int                `json:"collateralAssetPrecision"`
+	Active                   bool               `json:"active"`
+	Status                   MarketStatus       `json:"status"`
+	MarketStats              MarketStatsModel   `json:"marketStats"`
+	TradingConfig            TradingConfigModel `json:"tradingConfig"`
+	L2Config                 L2ConfigModel      `json:"l2Config"`
+}
+
+// RiskFactor returns the margin requirement for a position of the given notional value.
+// Values above the last tier use the last tier's risk factor.
+func (m MarketModel) RiskFactor(positionValue decimal.Decimal) (decimal.Decimal, error) {
+	tiers := m.TradingConfig.RiskFactorConfig
+	if len(tiers) == 0 {
+		return decimal.Zero, fmt.Errorf("market %s has no risk factor config", m.Name)
+	}
+	for _, tier := range tiers {
+		if positionValue.LessThanOrEqual(tier.UpperBound) {
+			return tier.RiskFactor, nil
+		}
+	}
+	return tiers[len(tiers)-1].RiskFactor, nil
+}
+
+// ValidateLeverage checks a leverage setting against the market's maximum leverage
+func (m MarketModel) ValidateLeverage(leverage decimal.Decimal) error {
+	if !leverage.IsPositive() {
+		return fmt.Errorf("leverage must be positive, got %s", leverage)
+	}
+	if m.TradingConfig.MaxLeverage.IsPositive() && leverage.GreaterThan(m.TradingConfig.MaxLeverage) {
+		return fmt.Errorf("leverage %s exceeds the maximum of %s for market %s", leverage, m.TradingConfig.MaxLeverage, m.Name)
+	}
+	return nil
+}
+
+var (
+	ErrOrderBelowMinSize  = errors.New("order size is below the market minimum")
+	ErrOrderAboveMaxValue = errors.New("order value is above the market maximum")
+	ErrInvalidQtyStep     = errors.New("order size is not a multiple of the market size step")
+	ErrInvalidPriceTick   = errors.New("order price is not a multiple of the market tick size")
+	ErrPriceOutsideBand   = errors.New("order price is outside the market price band")
+)
+
+// OrderValidationError describes why an order was rejected locally. It wraps one of
+// the ErrOrder*/ErrInvalid*/ErrPrice* sentinels, so callers can use errors.Is.
+type OrderValidationError struct {
+	Market string
+	Err    error
+	Detail string
+}
+
+func (e *OrderValidationError) Error() string {
+	return fmt.Sprintf("%s: %s (%s)", e.Market, e.Err, e.Detail)
+}
+
+func (e *OrderValidationError) Unwrap() error {
+	return e.Err
+}
+
+func (m MarketModel) validationError(err error, format string, args ...interface{}) error {
+	return &OrderValidationError{Market: m.Name, Err: err, Detail: fmt.Sprintf(format, args...)}
+}
+
+// roundToStep rounds value to a multiple of step, up or down. A non-positive step leaves value unchanged.
+func roundToStep(value, step decimal.Decimal, up bool) decimal.Decimal {
+	if !step.IsPositive() {
+		return value
+	}
+	steps := value.Div(step)
+	if up {
+		steps = steps.Ceil()
+	} else {
+		steps = steps.Floor()
+	}
+	return steps.Mul(step)
+}
+
+// RoundPrice rounds a price to the market tick size in the direction that never
+// worsens the price for the given side: down for buys, up for sells.
+func (m MarketModel) RoundPrice(price decimal.Decimal, side OrderSide) decimal.Decimal {
+	return roundToStep(price, m.TradingConfig.MinPriceChange, side == OrderSideSell)
+}
+
+// RoundQty rounds a synthetic amount down to the market size step, so the rounded
+// order is never larger than requested.
+func (m MarketModel) RoundQty(qty decimal.Decimal) decimal.Decimal {
+	return roundToStep(qty, m.TradingConfig.MinOrderSizeChange, false)
+}
+
+// ValidateOrder checks an order's size, price tick and value against the market's
+// trading config. Limits that are not set on the market (zero values) are skipped.
+// Price bands depend on a current mark price and are checked by ValidatePriceBand.
+func (m MarketModel) ValidateOrder(orderType OrderType, side OrderSide, qty, price decimal.Decimal) error {
+	cfg := m.TradingConfig
+
+	if cfg.MinOrderSize.IsPositive() && qty.LessThan(cfg.MinOrderSize) {
+		return m.validationError(ErrOrderBelowMinSize, "size %s, minimum %s", qty, cfg.MinOrderSize)
+	}
+	if cfg.MinOrderSizeChange.IsPositive() && !qty.Mod(cfg.MinOrderSizeChange).IsZero() {
+		return m.validationError(ErrInvalidQtyStep, "size %s, step %s", qty, cfg.MinOrderSizeChange)
+	}
+	if err := m.ValidatePriceTick(price); err != nil {
+		return err
+	}
+
+	maxValue := cfg.MaxLimitOrderValue
+	if orderType == OrderTypeMarket {
+		maxValue = cfg.MaxMarketOrderValue
+	}
+	if value := qty.Mul(price); maxValue.IsPositive() && value.GreaterThan(maxValue) {
+		return m.validationError(ErrOrderAboveMaxValue, "value %s, maximum %s", value, maxValue)
+	}
+
+	return nil
+}
+
+// ValidatePriceTick checks that a price is a multiple of the market tick size
+func (m MarketModel) ValidatePriceTick(price decimal.Decimal) error {
+	tick := m.TradingConfig.MinPriceChange
+	if tick.IsPositive() && !price.Mod(tick).IsZero() {
+		return m.validationError(ErrInvalidPriceTick, "price %s, tick %s", price, tick)
+	}
+	return nil
+}
+
+// ValidatePriceBand checks a price against the market's limit price band around
+// markPrice. The mark price moves constantly, so pass a recent one (e.g. from
+// GetMarketStats or SubscribeMarkPrices) rather than the stats cached on the model.
+// The check is skipped when markPrice is not positive.
+func (m MarketModel) ValidatePriceBand(side OrderSide, price, markPrice decimal.Decimal) error {
+	if !markPrice.IsPositive() {
+		return nil
+	}
+	cfg := m.TradingConfig
+	one := decimal.NewFromInt(1)
+	if side == OrderSideBuy && cfg.LimitPriceCap.IsPositive() {
+		if maxPrice := markPrice.Mul(one.Add(cfg.LimitPriceCap)); price.GreaterThan(maxPrice) {
+			return m.validationError(ErrPriceOutsideBand, "buy price %s above %s", price, maxPrice)
+		}
+	}
+	if side == OrderSideSell && cfg.LimitPriceFloor.IsPositive() {
+		if minPrice := markPrice.Mul(one.Sub(cfg.LimitPriceFloor)); price.LessThan(minPrice) {
+			return m.validationError(ErrPriceOutsideBand, "sell price %s below %s", price, minPrice)
+		}
+	}
+	return nil
+}
+
+// PriceUpdate is a mark or index price received from a price stream
+type PriceUpdate struct {
+	Market    string
+	Price     decimal.Decimal
+	Timestamp int64
+	Seq       int64
 }
@@ -0,0 +1,151 @@
+package sdk
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMarketJSON = `{
+	"name": "BTC-USD",
+	"assetName": "BTC",
+	"assetPrecision": 5,
+	"collateralAssetName": "USD",
+	"collateralAssetPrecision": 6,
+	"active": true,
+	"status": "ACTIVE",
+	"marketStats": {
+		"dailyVolume": "2410524.8466",
+		"dailyVolumeBase": "56.12",
+		"dailyPriceChange": "-412.5",
+		"dailyPriceChangePercentage": "-0.0095",
+		"dailyLow": "42510",
+		"dailyHigh": "43990",
+		"lastPrice": "43001",
+		"askPrice": "43002",
+		"bidPrice": "43000",
+		"markPrice": "43001.2",
+		"indexPrice": "43003.1",
+		"fundingRate": "0.000013",
+		"nextFundingRate": 1704423600000,
+		"openInterest": "1204571.2",
+		"openInterestBase": "28.01"
+	},
+	"tradingConfig": {
+		"minOrderSize": "0.0001",
+		"minOrderSizeChange": "0.00001",
+		"minPriceChange": "1",
+		"maxMarketOrderValue": "1000000",
+		"maxLimitOrderValue": "5000000",
+		"maxPositionValue": "10000000",
+		"maxLeverage": "50.00",
+		"maxNumOrders": "200",
+		"limitPriceCap": "0.05",
+		"limitPriceFloor": "0.05",
+		"riskFactorConfig": [
+			{"upperBound": "400000", "riskFactor": "0.02"},
+			{"upperBound": "800000", "riskFactor": "0.04"},
+			{"upperBound": "1200000", "riskFactor": "0.06"}
+		]
+	},
+	"l2Config": {
+		"type": "STARKX",
+		"collateralId": "0x31857064564ed0ff978e687456963cba09c2c6985d8f9300a1de4962fafa054",
+		"collateralResolution": 1000000,
+		"syntheticId": "0x4254432d3600000000000000000000",
+		"syntheticResolution": 1000000
+	}
+}`
+
+func TestMarketModel_Unmarshal(t *testing.T) {
+	var market MarketModel
+	require.NoError(t, json.Unmarshal([]byte(testMarketJSON), &market))
+
+	assert.Equal(t, MarketStatusActive, market.Status)
+	assert.True(t, market.TradingConfig.MinOrderSize.Equal(decimal.RequireFromString("0.0001")))
+	assert.True(t, market.TradingConfig.MinOrderSizeChange.Equal(decimal.RequireFromString("0.00001")))
+	assert.True(t, market.TradingConfig.MinPriceChange.Equal(decimal.NewFromInt(1)))
+	assert.True(t, market.TradingConfig.MaxLeverage.Equal(decimal.NewFromInt(50)))
+	assert.True(t, market.TradingConfig.MaxPositionValue.Equal(decimal.NewFromInt(10000000)))
+	assert.Len(t, market.TradingConfig.RiskFactorConfig, 3)
+	assert.True(t, market.MarketStats.MarkPrice.Equal(decimal.RequireFromString("43001.2")))
+	assert.Equal(t, int64(1704423600000), market.MarketStats.NextFundingRate)
+	assert.Equal(t, int64(1000000), market.L2Config.SyntheticResolution)
+}
+
+func TestMarketModel_RiskFactorAndLeverage(t *testing.T) {
+	var market MarketModel
+	require.NoError(t, json.Unmarshal([]byte(testMarketJSON), &market))
+
+	riskFactor, err := market.RiskFactor(decimal.NewFromInt(500000))
+	require.NoError(t, err)
+	assert.True(t, riskFactor.Equal(decimal.RequireFromString("0.04")))
+
+	riskFactor, err = market.RiskFactor(decimal.NewFromInt(5000000))
+	require.NoError(t, err)
+	assert.True(t, riskFactor.Equal(decimal.RequireFromString("0.06")), "Values above the last tier use the last tier")
+
+	assert.NoError(t, market.ValidateLeverage(decimal.NewFromInt(50)))
+	assert.Error(t, market.ValidateLeverage(decimal.NewFromInt(51)))
+	assert.Error(t, market.ValidateLeverage(decimal.Zero))
+}
+
+func TestMarketModel_Rounding(t *testing.T) {
+	var market MarketModel
+	require.NoError(t, json.Unmarshal([]byte(testMarketJSON), &market))
+
+	assert.Equal(t, "43000", market.RoundPrice(decimal.RequireFromString("43000.7"), OrderSideBuy).String())
+	assert.Equal(t, "43001", market.RoundPrice(decimal.RequireFromString("43000.2"), OrderSideSell).String())
+	assert.Equal(t, "43000", market.RoundPrice(decimal.RequireFromString("43000"), OrderSideSell).String())
+	assert.Equal(t, "0.01234", market.RoundQty(decimal.RequireFromString("0.012349")).String())
+}
+
+func TestMarketModel_ValidateOrder(t *testing.T) {
+	var market MarketModel
+	require.NoError(t, json.Unmarshal([]byte(testMarketJSON), &market))
+
+	tests := []struct {
+		name      string
+		orderType OrderType
+		side      OrderSide
+		qty       string
+		price     string
+		err       error
+	}{
+		{"valid", OrderTypeLimit, OrderSideBuy, "0.01", "43000", nil},
+		{"below min size", OrderTypeLimit, OrderSideBuy, "0.00005", "43000", ErrOrderBelowMinSize},
+		{"qty step", OrderTypeLimit, OrderSideBuy, "0.000105", "43000", ErrInvalidQtyStep},
+		{"price tick", OrderTypeLimit, OrderSideBuy, "0.01", "43000.5", ErrInvalidPriceTick},
+		{"limit value", OrderTypeLimit, OrderSideSell, "120", "43000", ErrOrderAboveMaxValue},
+		{"market value", OrderTypeMarket, OrderSideSell, "24", "43000", ErrOrderAboveMaxValue},
+		{"outside band", OrderTypeLimit, OrderSideBuy, "0.01", "46000", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := market.ValidateOrder(tt.orderType, tt.side, decimal.RequireFromString(tt.qty), decimal.RequireFromString(tt.price))
+			if tt.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.err)
+			var validationErr *OrderValidationError
+			assert.ErrorAs(t, err, &validationErr)
+		})
+	}
+}
+
+func TestMarketModel_ValidatePriceBand(t *testing.T) {
+	var market MarketModel
+	require.NoError(t, json.Unmarshal([]byte(testMarketJSON), &market))
+	markPrice := decimal.RequireFromString("43000")
+
+	assert.NoError(t, market.ValidatePriceBand(OrderSideBuy, decimal.RequireFromString("45150"), markPrice))
+	assert.ErrorIs(t, market.ValidatePriceBand(OrderSideBuy, decimal.RequireFromString("45151"), markPrice), ErrPriceOutsideBand)
+	assert.ErrorIs(t, market.ValidatePriceBand(OrderSideSell, decimal.RequireFromString("40849"), markPrice), ErrPriceOutsideBand)
+	assert.NoError(t, market.ValidatePriceBand(OrderSideBuy, decimal.RequireFromString("1"), markPrice), "Buys far below the mark are allowed")
+	assert.NoError(t, market.ValidatePriceBand(OrderSideBuy, decimal.RequireFromString("46000"), decimal.Zero), "Without a mark price the band is not checked")
+}
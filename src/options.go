@@ -0,0 +1,108 @@
+package sdk
+
+import (
+	"net/http"
+	"time"
+)
+
+// Middleware wraps the transport used for every request of an APIClient, e.g. to add headers,
+// request signing, logging or tracing
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to http.RoundTripper, which is handy for writing middleware
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// ClientOption configures an APIClient
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	apiKey        string
+	apiKeySet     bool
+	starkAccount  *StarkPerpetualAccount
+	clientTimeout time.Duration
+	httpClient    *http.Client
+	transport     http.RoundTripper
+	middleware    []Middleware
+}
+
+// DefaultClientTimeout is the HTTP timeout of clients created by NewClient without WithTimeout
+const DefaultClientTimeout = 30 * time.Second
+
+// WithAPIKey sets the API key sent with every request. It overrides the key of the account
+// given with WithStarkAccount, even when empty.
+func WithAPIKey(apiKey string) ClientOption {
+	return func(o *clientOptions) {
+		o.apiKey = apiKey
+		o.apiKeySet = true
+	}
+}
+
+// WithStarkAccount sets the account orders are signed for. Its API key is used unless
+// WithAPIKey is also given.
+func WithStarkAccount(account *StarkPerpetualAccount) ClientOption {
+	return func(o *clientOptions) {
+		o.starkAccount = account
+	}
+}
+
+// WithTimeout sets the timeout of the HTTP client. It does not apply to clients given with WithHTTPClient.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.clientTimeout = timeout
+	}
+}
+
+// WithHTTPClient makes the client send requests with httpClient instead of a client built from
+// the timeout. The given client is not modified; transport and middleware options apply to a copy.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(o *clientOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithTransport replaces the transport of the HTTP client, e.g. to configure proxies or mTLS
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(o *clientOptions) {
+		o.transport = transport
+	}
+}
+
+// WithMiddleware wraps the transport with middleware. The first middleware given sees each
+// request first; repeated options append to the chain.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(o *clientOptions) {
+		o.middleware = append(o.middleware, middleware...)
+	}
+}
+
+// buildHTTPClient returns the HTTP client for the options, or nil for BaseModule's default client
+func (o *clientOptions) buildHTTPClient(clientTimeout time.Duration) *http.Client {
+	if o.transport == nil && len(o.middleware) == 0 {
+		return o.httpClient
+	}
+
+	var client http.Client
+	if o.httpClient != nil {
+		client = *o.httpClient
+	} else {
+		client.Timeout = clientTimeout
+	}
+
+	transport := client.Transport
+	if o.transport != nil {
+		transport = o.transport
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(o.middleware) - 1; i >= 0; i-- {
+		transport = o.middleware[i](transport)
+	}
+	client.Transport = transport
+
+	return &client
+}
@@ -0,0 +1,166 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func headerMiddleware(name, value string, order *[]string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			*order = append(*order, value)
+			req.Header.Add(name, value)
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+func newOptionsTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Seen-Trace", r.Header.Get("X-Trace"))
+		assert.Equal(t, TestAPIKey, r.Header.Get("X-API-Key"))
+		w.Write([]byte(`{"status":"OK","data":{"equity":"100"}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAPIClient_WithMiddleware(t *testing.T) {
+	server := newOptionsTestServer(t)
+
+	var order []string
+	client := NewAPIClient(EndpointConfig{APIBaseURL: server.URL}, TestAPIKey, nil, 5*time.Second,
+		WithMiddleware(headerMiddleware("X-Trace", "outer", &order)),
+		WithMiddleware(headerMiddleware("X-Trace", "inner", &order)),
+	)
+
+	_, err := client.GetBalance(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"outer", "inner"}, order, "Middleware should run in the order given")
+	assert.Equal(t, 5*time.Second, client.HTTPClient().Timeout)
+
+	// Middleware survives Close
+	client.Close()
+	_, err = client.GetBalance(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, order, 4)
+}
+
+func TestAPIClient_WithTransport(t *testing.T) {
+	server := newOptionsTestServer(t)
+
+	var calls int
+	transport := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	var order []string
+	client := NewAPIClient(EndpointConfig{APIBaseURL: server.URL}, TestAPIKey, nil, 5*time.Second,
+		WithTransport(transport),
+		WithMiddleware(headerMiddleware("X-Trace", "mw", &order)),
+	)
+
+	_, err := client.GetBalance(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, []string{"mw"}, order, "Middleware should wrap the custom transport")
+}
+
+func TestAPIClient_WithHTTPClient(t *testing.T) {
+	server := newOptionsTestServer(t)
+
+	httpClient := &http.Client{Timeout: time.Minute}
+	client := NewAPIClient(EndpointConfig{APIBaseURL: server.URL}, TestAPIKey, nil, 5*time.Second, WithHTTPClient(httpClient))
+	assert.Same(t, httpClient, client.HTTPClient())
+
+	var order []string
+	client = NewAPIClient(EndpointConfig{APIBaseURL: server.URL}, TestAPIKey, nil, 5*time.Second,
+		WithHTTPClient(httpClient),
+		WithMiddleware(headerMiddleware("X-Trace", "mw", &order)),
+	)
+	assert.Equal(t, time.Minute, client.HTTPClient().Timeout, "The given client's settings should be kept")
+	assert.Nil(t, httpClient.Transport, "The given client must not be modified")
+
+	_, err := client.GetBalance(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mw"}, order)
+}
+
+func TestNewClient_Options(t *testing.T) {
+	account, err := createTestAccount()
+	require.NoError(t, err)
+
+	client := NewClient(TestnetConfig(), WithStarkAccount(account))
+	apiKey, err := client.APIKey()
+	require.NoError(t, err)
+	assert.Equal(t, account.APIKey(), apiKey, "The account's API key should be used by default")
+	assert.Equal(t, DefaultClientTimeout, client.HTTPClient().Timeout)
+	assert.Equal(t, "SN_SEPOLIA", client.StarknetDomain().ChainID)
+
+	signer, err := client.StarkAccount()
+	require.NoError(t, err)
+	assert.Same(t, account, signer)
+
+	client = NewClient(MainnetConfig(), WithStarkAccount(account), WithAPIKey("other-key"), WithTimeout(time.Second))
+	apiKey, err = client.APIKey()
+	require.NoError(t, err)
+	assert.Equal(t, "other-key", apiKey)
+	assert.Equal(t, time.Second, client.HTTPClient().Timeout)
+
+	_, err = NewClient(TestnetConfig()).APIKey()
+	assert.ErrorIs(t, err, ErrAPIKeyNotSet)
+
+	_, err = NewAPIClient(TestnetConfig(), "", account, time.Second).APIKey()
+	assert.ErrorIs(t, err, ErrAPIKeyNotSet, "The deprecated constructor should not fall back to the account's key")
+}
+
+func TestNewClient_ConcurrentFirstRequests(t *testing.T) {
+	server := newOptionsTestServer(t)
+	cfg := EndpointConfig{APIBaseURL: server.URL}
+	clients := map[string]*APIClient{
+		"NewClient":     NewClient(cfg, WithAPIKey(TestAPIKey)),
+		"NewBaseModule": {BaseModule: NewBaseModule(cfg, TestAPIKey, nil, nil, time.Second)},
+	}
+
+	for name, client := range clients {
+		t.Run(name, func(t *testing.T) {
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := client.GetBalance(context.Background())
+					assert.NoError(t, err)
+					client.Close()
+				}()
+			}
+			wg.Wait()
+		})
+	}
+}
+
+func TestEnvironmentPresets(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg     EndpointConfig
+		host    string
+		chainID string
+	}{
+		"mainnet": {MainnetConfig(), "api.starknet.extended.exchange", "SN_MAIN"},
+		"testnet": {TestnetConfig(), "api.starknet.sepolia.extended.exchange", "SN_SEPOLIA"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, "https://"+tc.host+"/api/v1", tc.cfg.APIBaseURL)
+			assert.Equal(t, "wss://"+tc.host+"/stream.extended.exchange/v1", tc.cfg.StreamURL)
+			assert.Equal(t, "https://"+tc.host, tc.cfg.OnboardingURL)
+			assert.Equal(t, StarknetDomain{Name: "Perpetuals", Version: "v0", ChainID: tc.chainID, Revision: "1"}, tc.cfg.StarknetDomain)
+		})
+	}
+}
@@ -0,0 +1,401 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInsufficientLiquidity   = errors.New("not enough liquidity in the order book")
+	ErrOrderbookNotInitialized = errors.New("order book has no snapshot")
+	ErrOrderbookSequenceGap    = errors.New("order book sequence gap")
+)
+
+// OrderbookLevel is a single price level of the order book
+type OrderbookLevel struct {
+	Price decimal.Decimal `json:"price"`
+	Qty   decimal.Decimal `json:"qty"`
+}
+
+// OrderbookModel is an order book snapshot. Bids are sorted by descending price
+// and asks by ascending price, so the first level of each is the best.
+type OrderbookModel struct {
+	Market string           `json:"market"`
+	Bid    []OrderbookLevel `json:"bid"`
+	Ask    []OrderbookLevel `json:"ask"`
+}
+
+// BestBid returns the highest bid, or false if there are no bids
+func (o *OrderbookModel) BestBid() (OrderbookLevel, bool) {
+	if len(o.Bid) == 0 {
+		return OrderbookLevel{}, false
+	}
+	return o.Bid[0], true
+}
+
+// BestAsk returns the lowest ask, or false if there are no asks
+func (o *OrderbookModel) BestAsk() (OrderbookLevel, bool) {
+	if len(o.Ask) == 0 {
+		return OrderbookLevel{}, false
+	}
+	return o.Ask[0], true
+}
+
+// Mid returns the midpoint between the best bid and ask, or false if either side is empty
+func (o *OrderbookModel) Mid() (decimal.Decimal, bool) {
+	bid, okBid := o.BestBid()
+	ask, okAsk := o.BestAsk()
+	if !okBid || !okAsk {
+		return decimal.Zero, false
+	}
+	return bid.Price.Add(ask.Price).Div(decimal.NewFromInt(2)), true
+}
+
+// Spread returns the difference between the best ask and bid, or false if either side is empty
+func (o *OrderbookModel) Spread() (decimal.Decimal, bool) {
+	bid, okBid := o.BestBid()
+	ask, okAsk := o.BestAsk()
+	if !okBid || !okAsk {
+		return decimal.Zero, false
+	}
+	return ask.Price.Sub(bid.Price), true
+}
+
+// levelsFor returns the levels a taker on the given side trades against
+func (o *OrderbookModel) levelsFor(side OrderSide) []OrderbookLevel {
+	if side == OrderSideBuy {
+		return o.Ask
+	}
+	return o.Bid
+}
+
+// DepthToNotional walks the book from the top for a taker on the given side until
+// notional (price * qty) is reached. It returns the synthetic qty that can be filled
+// and the worst price touched; if the book is exhausted first, the totals for the
+// whole side are returned along with ErrInsufficientLiquidity.
+func (o *OrderbookModel) DepthToNotional(side OrderSide, notional decimal.Decimal) (decimal.Decimal, decimal.Decimal, error) {
+	qty := decimal.Zero
+	worstPrice := decimal.Zero
+	remaining := notional
+
+	for _, level := range o.levelsFor(side) {
+		if !remaining.IsPositive() {
+			break
+		}
+		worstPrice = level.Price
+		levelNotional := level.Price.Mul(level.Qty)
+		if levelNotional.GreaterThanOrEqual(remaining) {
+			return qty.Add(remaining.Div(level.Price)), worstPrice, nil
+		}
+		qty = qty.Add(level.Qty)
+		remaining = remaining.Sub(levelNotional)
+	}
+
+	if remaining.IsPositive() {
+		return qty, worstPrice, ErrInsufficientLiquidity
+	}
+	return qty, worstPrice, nil
+}
+
+// VWAP returns the volume weighted average price to fill size for a taker on the given side
+func (o *OrderbookModel) VWAP(side OrderSide, size decimal.Decimal) (decimal.Decimal, error) {
+	if !size.IsPositive() {
+		return decimal.Zero, errors.New("size must be positive")
+	}
+
+	filled := decimal.Zero
+	cost := decimal.Zero
+	for _, level := range o.levelsFor(side) {
+		take := decimal.Min(level.Qty, size.Sub(filled))
+		filled = filled.Add(take)
+		cost = cost.Add(take.Mul(level.Price))
+		if filled.Equal(size) {
+			return cost.Div(filled), nil
+		}
+	}
+
+	return decimal.Zero, ErrInsufficientLiquidity
+}
+
+// OrderbookUpdateType tells whether a streamed order book message replaces the book or amends it
+type OrderbookUpdateType string
+
+const (
+	OrderbookUpdateSnapshot OrderbookUpdateType = "SNAPSHOT"
+	OrderbookUpdateDelta    OrderbookUpdateType = "DELTA"
+)
+
+// OrderbookUpdate is an order book message received from the stream. Snapshot levels carry
+// the full quantity at each price, while delta levels carry the change in quantity.
+type OrderbookUpdate struct {
+	Type      OrderbookUpdateType
+	Market    string
+	Bid       []OrderbookLevel
+	Ask       []OrderbookLevel
+	Seq       int64
+	Timestamp int64
+}
+
+// bookSide holds the levels of one side of an OrderBook sorted from best to worst
+type bookSide struct {
+	levels     []OrderbookLevel
+	descending bool
+}
+
+func (b *bookSide) compare(level OrderbookLevel, price decimal.Decimal) int {
+	if b.descending {
+		return price.Cmp(level.Price)
+	}
+	return level.Price.Cmp(price)
+}
+
+func (b *bookSide) reset(levels []OrderbookLevel) {
+	b.levels = b.levels[:0]
+	for _, level := range levels {
+		b.set(level.Price, level.Qty)
+	}
+}
+
+// set replaces the quantity at price, removing the level when qty is not positive
+func (b *bookSide) set(price, qty decimal.Decimal) {
+	i, found := slices.BinarySearchFunc(b.levels, price, b.compare)
+	switch {
+	case !qty.IsPositive():
+		if found {
+			b.levels = slices.Delete(b.levels, i, i+1)
+		}
+	case found:
+		b.levels[i].Qty = qty
+	default:
+		b.levels = slices.Insert(b.levels, i, OrderbookLevel{Price: price, Qty: qty})
+	}
+}
+
+// add applies a quantity change at price
+func (b *bookSide) add(price, delta decimal.Decimal) {
+	i, found := slices.BinarySearchFunc(b.levels, price, b.compare)
+	if found {
+		b.set(price, b.levels[i].Qty.Add(delta))
+	} else {
+		b.set(price, delta)
+	}
+}
+
+func (b *bookSide) top(n int) []OrderbookLevel {
+	if n <= 0 || n > len(b.levels) {
+		n = len(b.levels)
+	}
+	return slices.Clone(b.levels[:n])
+}
+
+// OrderBookChange notifies an OrderBook subscriber that the book was updated
+type OrderBookChange struct {
+	Market string
+	Type   OrderbookUpdateType
+	Seq    int64
+	// TopChanged is set when the best bid or best ask price or quantity changed
+	TopChanged bool
+}
+
+// OrderBook is a locally maintained L2 order book, built from a snapshot (REST or stream)
+// and kept current with stream deltas. It is safe for concurrent use.
+type OrderBook struct {
+	market string
+
+	mu          sync.RWMutex
+	bid         bookSide
+	ask         bookSide
+	seq         int64
+	initialized bool
+	listeners   map[chan OrderBookChange]struct{}
+}
+
+// NewOrderBook creates an empty order book for market. It must receive a snapshot before deltas.
+func NewOrderBook(market string) *OrderBook {
+	return &OrderBook{
+		market:    market,
+		bid:       bookSide{descending: true},
+		listeners: map[chan OrderBookChange]struct{}{},
+	}
+}
+
+// Market returns the market the book tracks
+func (b *OrderBook) Market() string {
+	return b.market
+}
+
+// Seq returns the sequence number of the last applied update, 0 for REST snapshots
+func (b *OrderBook) Seq() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.seq
+}
+
+// Ready reports whether the book holds a snapshot and has not since detected a gap
+func (b *OrderBook) Ready() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.initialized
+}
+
+// ApplySnapshot replaces the book with a snapshot. Pass seq 0 for REST snapshots, in which
+// case the next delta is accepted without a continuity check.
+func (b *OrderBook) ApplySnapshot(snapshot *OrderbookModel, seq int64) error {
+	if snapshot.Market != "" && snapshot.Market != b.market {
+		return fmt.Errorf("snapshot for %s applied to order book of %s", snapshot.Market, b.market)
+	}
+
+	b.mu.Lock()
+	b.bid.reset(snapshot.Bid)
+	b.ask.reset(snapshot.Ask)
+	b.seq = seq
+	b.initialized = true
+	b.notifyLocked(OrderBookChange{Market: b.market, Type: OrderbookUpdateSnapshot, Seq: seq, TopChanged: true})
+	b.mu.Unlock()
+	return nil
+}
+
+// Apply applies a streamed snapshot or delta. A delta that does not directly follow the
+// last applied sequence number returns ErrOrderbookSequenceGap and leaves the book
+// uninitialized until the next snapshot.
+func (b *OrderBook) Apply(update OrderbookUpdate) error {
+	if update.Market != "" && update.Market != b.market {
+		return fmt.Errorf("update for %s applied to order book of %s", update.Market, b.market)
+	}
+
+	switch update.Type {
+	case OrderbookUpdateSnapshot:
+		return b.ApplySnapshot(&OrderbookModel{Bid: update.Bid, Ask: update.Ask}, update.Seq)
+	case OrderbookUpdateDelta:
+	default:
+		return fmt.Errorf("unknown order book update type %q", update.Type)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.initialized {
+		return ErrOrderbookNotInitialized
+	}
+	if b.seq != 0 && update.Seq != b.seq+1 {
+		b.initialized = false
+		return fmt.Errorf("%w: expected %d, got %d", ErrOrderbookSequenceGap, b.seq+1, update.Seq)
+	}
+
+	bestBid, bestAsk := b.bestLocked(&b.bid), b.bestLocked(&b.ask)
+	for _, level := range update.Bid {
+		b.bid.add(level.Price, level.Qty)
+	}
+	for _, level := range update.Ask {
+		b.ask.add(level.Price, level.Qty)
+	}
+	b.seq = update.Seq
+
+	topChanged := !sameLevel(bestBid, b.bestLocked(&b.bid)) || !sameLevel(bestAsk, b.bestLocked(&b.ask))
+	b.notifyLocked(OrderBookChange{Market: b.market, Type: OrderbookUpdateDelta, Seq: update.Seq, TopChanged: topChanged})
+	return nil
+}
+
+func (b *OrderBook) bestLocked(side *bookSide) *OrderbookLevel {
+	if len(side.levels) == 0 {
+		return nil
+	}
+	level := side.levels[0]
+	return &level
+}
+
+func sameLevel(a, b *OrderbookLevel) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Price.Equal(b.Price) && a.Qty.Equal(b.Qty)
+}
+
+// BestBid returns the highest bid, or false if there are no bids
+func (b *OrderBook) BestBid() (OrderbookLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if level := b.bestLocked(&b.bid); level != nil {
+		return *level, true
+	}
+	return OrderbookLevel{}, false
+}
+
+// BestAsk returns the lowest ask, or false if there are no asks
+func (b *OrderBook) BestAsk() (OrderbookLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if level := b.bestLocked(&b.ask); level != nil {
+		return *level, true
+	}
+	return OrderbookLevel{}, false
+}
+
+// Top returns a copy of the best n levels of each side, or of the whole book if n <= 0.
+// The returned model can be used for Mid, Spread, DepthToNotional and VWAP calculations.
+func (b *OrderBook) Top(n int) *OrderbookModel {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return &OrderbookModel{
+		Market: b.market,
+		Bid:    b.bid.top(n),
+		Ask:    b.ask.top(n),
+	}
+}
+
+// Depth returns the total quantity a taker on the given side can fill at prices
+// no worse than limitPrice
+func (b *OrderBook) Depth(side OrderSide, limitPrice decimal.Decimal) decimal.Decimal {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	levels := b.bid.levels
+	if side == OrderSideBuy {
+		levels = b.ask.levels
+	}
+
+	total := decimal.Zero
+	for _, level := range levels {
+		if (side == OrderSideBuy && level.Price.GreaterThan(limitPrice)) ||
+			(side == OrderSideSell && level.Price.LessThan(limitPrice)) {
+			break
+		}
+		total = total.Add(level.Qty)
+	}
+	return total
+}
+
+// Subscribe returns a channel notified after every applied update, and a function
+// that unsubscribes and closes it. Notifications are dropped while the buffer is full,
+// so slow readers should read the latest state from the book rather than rely on
+// receiving every change.
+func (b *OrderBook) Subscribe(buffer int) (<-chan OrderBookChange, func()) {
+	ch := make(chan OrderBookChange, buffer)
+
+	b.mu.Lock()
+	b.listeners[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.listeners, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+func (b *OrderBook) notifyLocked(change OrderBookChange) {
+	for ch := range b.listeners {
+		select {
+		case ch <- change:
+		default:
+		}
+	}
+}
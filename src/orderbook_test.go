@@ -0,0 +1,184 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func level(price, qty string) OrderbookLevel {
+	return OrderbookLevel{Price: decimal.RequireFromString(price), Qty: decimal.RequireFromString(qty)}
+}
+
+func createTestOrderbook() *OrderbookModel {
+	return &OrderbookModel{
+		Market: "BTC-USD",
+		Bid:    []OrderbookLevel{level("99", "1"), level("98", "2"), level("97", "3")},
+		Ask:    []OrderbookLevel{level("101", "1"), level("102", "2"), level("103", "3")},
+	}
+}
+
+func TestOrderbookModel_TopOfBook(t *testing.T) {
+	book := createTestOrderbook()
+
+	bid, ok := book.BestBid()
+	require.True(t, ok)
+	assert.Equal(t, "99", bid.Price.String())
+
+	ask, ok := book.BestAsk()
+	require.True(t, ok)
+	assert.Equal(t, "101", ask.Price.String())
+
+	mid, ok := book.Mid()
+	require.True(t, ok)
+	assert.Equal(t, "100", mid.String())
+
+	spread, ok := book.Spread()
+	require.True(t, ok)
+	assert.Equal(t, "2", spread.String())
+
+	_, ok = (&OrderbookModel{Bid: book.Bid}).Mid()
+	assert.False(t, ok, "Mid should be unavailable with an empty side")
+}
+
+func TestOrderbookModel_DepthAndVWAP(t *testing.T) {
+	book := createTestOrderbook()
+
+	qty, worst, err := book.DepthToNotional(OrderSideBuy, decimal.NewFromInt(305))
+	require.NoError(t, err)
+	assert.Equal(t, "3", qty.String())
+	assert.Equal(t, "102", worst.String())
+
+	qty, worst, err = book.DepthToNotional(OrderSideSell, decimal.NewFromInt(10000))
+	assert.ErrorIs(t, err, ErrInsufficientLiquidity)
+	assert.Equal(t, "6", qty.String())
+	assert.Equal(t, "97", worst.String())
+
+	vwap, err := book.VWAP(OrderSideBuy, decimal.NewFromInt(3))
+	require.NoError(t, err)
+	assert.Equal(t, "101.6666666666666667", vwap.String())
+
+	vwap, err = book.VWAP(OrderSideSell, decimal.RequireFromString("0.5"))
+	require.NoError(t, err)
+	assert.Equal(t, "99", vwap.String())
+
+	_, err = book.VWAP(OrderSideBuy, decimal.NewFromInt(7))
+	assert.ErrorIs(t, err, ErrInsufficientLiquidity)
+}
+
+func TestOrderBook_SnapshotAndDeltas(t *testing.T) {
+	book := NewOrderBook("BTC-USD")
+	require.ErrorIs(t, book.Apply(OrderbookUpdate{Type: OrderbookUpdateDelta, Seq: 1}), ErrOrderbookNotInitialized)
+
+	// Levels are sorted regardless of the order they arrive in
+	require.NoError(t, book.Apply(OrderbookUpdate{
+		Type:   OrderbookUpdateSnapshot,
+		Market: "BTC-USD",
+		Bid:    []OrderbookLevel{level("98", "2"), level("99", "1")},
+		Ask:    []OrderbookLevel{level("102", "2"), level("101", "1")},
+		Seq:    1,
+	}))
+	assert.True(t, book.Ready())
+
+	bid, ok := book.BestBid()
+	require.True(t, ok)
+	assert.Equal(t, "99", bid.Price.String())
+
+	require.NoError(t, book.Apply(OrderbookUpdate{
+		Type: OrderbookUpdateDelta,
+		Bid:  []OrderbookLevel{level("99", "-1"), level("97", "3")},
+		Ask:  []OrderbookLevel{level("101", "0.5"), level("100", "4")},
+		Seq:  2,
+	}))
+
+	top := book.Top(0)
+	assert.Equal(t, []OrderbookLevel{level("98", "2"), level("97", "3")}, top.Bid)
+	assert.Equal(t, []OrderbookLevel{level("100", "4"), level("101", "1.5"), level("102", "2")}, top.Ask)
+	assert.Equal(t, int64(2), book.Seq())
+
+	top = book.Top(1)
+	assert.Len(t, top.Bid, 1)
+	spread, ok := top.Spread()
+	require.True(t, ok)
+	assert.Equal(t, "2", spread.String())
+
+	assert.Equal(t, "5.5", book.Depth(OrderSideBuy, decimal.NewFromInt(101)).String())
+	assert.Equal(t, "2", book.Depth(OrderSideSell, decimal.NewFromInt(98)).String())
+}
+
+func TestOrderBook_SequenceGap(t *testing.T) {
+	book := NewOrderBook("BTC-USD")
+	require.NoError(t, book.Apply(OrderbookUpdate{Type: OrderbookUpdateSnapshot, Bid: []OrderbookLevel{level("99", "1")}, Seq: 5}))
+
+	err := book.Apply(OrderbookUpdate{Type: OrderbookUpdateDelta, Bid: []OrderbookLevel{level("99", "1")}, Seq: 7})
+	require.ErrorIs(t, err, ErrOrderbookSequenceGap)
+	assert.False(t, book.Ready())
+	assert.ErrorIs(t, book.Apply(OrderbookUpdate{Type: OrderbookUpdateDelta, Seq: 8}), ErrOrderbookNotInitialized)
+
+	bid, _ := book.BestBid()
+	assert.Equal(t, "1", bid.Qty.String(), "A delta after a gap must not be applied")
+
+	require.NoError(t, book.Apply(OrderbookUpdate{Type: OrderbookUpdateSnapshot, Bid: []OrderbookLevel{level("98", "1")}, Seq: 1}))
+	assert.True(t, book.Ready())
+}
+
+func TestOrderBook_RESTSnapshotAcceptsAnyDelta(t *testing.T) {
+	book := NewOrderBook("BTC-USD")
+	require.NoError(t, book.ApplySnapshot(createTestOrderbook(), 0))
+	require.NoError(t, book.Apply(OrderbookUpdate{Type: OrderbookUpdateDelta, Ask: []OrderbookLevel{level("101", "-1")}, Seq: 42}))
+
+	ask, ok := book.BestAsk()
+	require.True(t, ok)
+	assert.Equal(t, "102", ask.Price.String())
+
+	assert.Error(t, book.ApplySnapshot(&OrderbookModel{Market: "ETH-USD"}, 0))
+	assert.Error(t, book.Apply(OrderbookUpdate{Type: OrderbookUpdateDelta, Market: "ETH-USD", Seq: 43}))
+}
+
+func TestOrderBook_ChangeNotifications(t *testing.T) {
+	book := NewOrderBook("BTC-USD")
+	changes, unsubscribe := book.Subscribe(8)
+
+	require.NoError(t, book.ApplySnapshot(createTestOrderbook(), 1))
+	require.NoError(t, book.Apply(OrderbookUpdate{Type: OrderbookUpdateDelta, Bid: []OrderbookLevel{level("97", "1")}, Seq: 2}))
+	require.NoError(t, book.Apply(OrderbookUpdate{Type: OrderbookUpdateDelta, Ask: []OrderbookLevel{level("101", "1")}, Seq: 3}))
+
+	assert.Equal(t, OrderBookChange{Market: "BTC-USD", Type: OrderbookUpdateSnapshot, Seq: 1, TopChanged: true}, <-changes)
+	assert.Equal(t, OrderBookChange{Market: "BTC-USD", Type: OrderbookUpdateDelta, Seq: 2, TopChanged: false}, <-changes)
+	assert.Equal(t, OrderBookChange{Market: "BTC-USD", Type: OrderbookUpdateDelta, Seq: 3, TopChanged: true}, <-changes)
+
+	unsubscribe()
+	_, ok := <-changes
+	assert.False(t, ok)
+	require.NoError(t, book.Apply(OrderbookUpdate{Type: OrderbookUpdateDelta, Seq: 4}))
+}
+
+func TestOrderBook_ConcurrentAccess(t *testing.T) {
+	book := NewOrderBook("BTC-USD")
+	require.NoError(t, book.ApplySnapshot(createTestOrderbook(), 1))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for seq := int64(2); seq < 500; seq++ {
+			qty := "1"
+			if seq%2 == 0 {
+				qty = "-1"
+			}
+			assert.NoError(t, book.Apply(OrderbookUpdate{Type: OrderbookUpdateDelta, Bid: []OrderbookLevel{level("96", qty)}, Seq: seq}))
+		}
+	}()
+
+	for {
+		select {
+		case <-done:
+			return
+		default:
+			book.BestBid()
+			book.Top(5)
+			book.Depth(OrderSideSell, decimal.Zero)
+		}
+	}
+}
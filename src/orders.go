@@ -123,6 +123,26 @@ type PerpetualOrderModel struct {
 	BuilderFee               *string                  `json:"builderFee,omitempty"`
 	BuilderID                *int                     `json:"builderId,omitempty"`
 	CancelID                 *string                  `json:"cancelId,omitempty"`
+
+	// domain is the Starknet domain the settlements were signed for
+	domain StarknetDomain
+}
+
+// ConditionalTriggerParams describes when a conditional order is activated
+type ConditionalTriggerParams struct {
+	TriggerPrice       decimal.Decimal
+	TriggerPriceType   TriggerPriceType
+	Direction          TriggerDirection
+	ExecutionPriceType ExecutionPriceType
+}
+
+// TpSlTriggerParams describes a take profit or stop loss leg. Each leg is settled
+// separately, closing the position at Price once TriggerPrice is reached.
+type TpSlTriggerParams struct {
+	TriggerPrice     decimal.Decimal
+	TriggerPriceType TriggerPriceType
+	Price            decimal.Decimal
+	PriceType        ExecutionPriceType
 }
 
 // CreateOrderObjectParams represents the parameters for creating an order object
@@ -136,6 +156,7 @@ type CreateOrderObjectParams struct {
 	StarknetDomain           StarknetDomain
 	ExpireTime               *time.Time
 	PostOnly                 bool
+	ReduceOnly               bool
 	PreviousOrderExternalID  *string
 	OrderExternalID          *string
 	TimeInForce              TimeInForce
@@ -143,32 +164,188 @@ type CreateOrderObjectParams struct {
 	Nonce                    *int
 	BuilderFee               *decimal.Decimal
 	BuilderID                *int
+	// Fees is the account's fee schedule for the market, as returned by GetMarketFee.
+	// Fees for another market are rejected. DefaultFees is used when nil.
+	Fees *TradingFeeModel
+	// OrderType defaults to OrderTypeLimit when empty.
+	OrderType OrderType
+	// Trigger is required for, and only allowed on, conditional orders.
+	Trigger *ConditionalTriggerParams
+	// TpSlType, TakeProfit and StopLoss attach protective legs to the order.
+	TpSlType   *TpSlType
+	TakeProfit *TpSlTriggerParams
+	StopLoss   *TpSlTriggerParams
+	// RoundToMarket rounds prices to the market tick size and the synthetic amount to
+	// the size step before validation and signing (see MarketModel.RoundPrice/RoundQty).
+	RoundToMarket bool
+	// Validate checks the order against the market's trading config before signing:
+	// size, value and the tick size of every price, including triggers and legs.
+	Validate bool
+	// MarkPrice is a recent mark price. When set with Validate, the order price is also
+	// checked against the market price band (see MarketModel.ValidatePriceBand).
+	MarkPrice *decimal.Decimal
 }
 
-// CreateOrderObject creates a PerpetualOrderModel with the given parameters
-func CreateOrderObject(params CreateOrderObjectParams) (*PerpetualOrderModel, error) {
+// orderSettlementParams holds what is needed to sign one side of an order
+type orderSettlementParams struct {
+	Side            OrderSide
+	SyntheticAmount decimal.Decimal
+	Price           decimal.Decimal
+	FeeRate         decimal.Decimal
+}
+
+// oppositeSide returns the side that closes a position opened by side
+func oppositeSide(side OrderSide) OrderSide {
+	if side == OrderSideBuy {
+		return OrderSideSell
+	}
+	return OrderSideBuy
+}
+
+// tpSlSide returns the side of the take profit and stop loss legs of an order
+func tpSlSide(params CreateOrderObjectParams) OrderSide {
+	// Standalone TPSL orders are already expressed from the closing side
+	if params.OrderType == OrderTypeTpsl {
+		return params.Side
+	}
+	return oppositeSide(params.Side)
+}
+
+// MarketOrderPrice returns the worst acceptable price for a market order, i.e. the
+// reference price moved against the taker by the given slippage (0.01 = 1%).
+func MarketOrderPrice(referencePrice decimal.Decimal, side OrderSide, slippage decimal.Decimal) decimal.Decimal {
+	if side == OrderSideBuy {
+		return referencePrice.Mul(decimal.NewFromInt(1).Add(slippage))
+	}
+	return referencePrice.Mul(decimal.NewFromInt(1).Sub(slippage))
+}
+
+// CreateMarketOrderObject creates an IOC market order whose price is bounded by
+// referencePrice and the allowed slippage.
+// The price is rounded to the market tick size so that it passes validation.
+func CreateMarketOrderObject(params CreateOrderObjectParams, referencePrice decimal.Decimal, slippage decimal.Decimal) (*PerpetualOrderModel, error) {
+	params.OrderType = OrderTypeMarket
+	params.TimeInForce = TimeInForceIOC
+	params.Price = params.Market.RoundPrice(MarketOrderPrice(referencePrice, params.Side, slippage), params.Side)
+	return CreateOrderObject(params)
+}
+
+// CreateConditionalOrderObject creates an order that is only placed once the trigger fires
+func CreateConditionalOrderObject(params CreateOrderObjectParams, trigger ConditionalTriggerParams) (*PerpetualOrderModel, error) {
+	params.OrderType = OrderTypeConditional
+	params.Trigger = &trigger
+	return CreateOrderObject(params)
+}
+
+// CreateTpSlOrderObject creates a standalone take profit / stop loss order. Side is the
+// side of the closing order, i.e. the opposite of the position being protected.
+func CreateTpSlOrderObject(params CreateOrderObjectParams, tpSlType TpSlType, takeProfit, stopLoss *TpSlTriggerParams) (*PerpetualOrderModel, error) {
+	params.OrderType = OrderTypeTpsl
+	params.TpSlType = &tpSlType
+	params.TakeProfit = takeProfit
+	params.StopLoss = stopLoss
+	params.ReduceOnly = true
+	return CreateOrderObject(params)
+}
+
+func validateOrderType(params CreateOrderObjectParams) error {
+	switch params.OrderType {
+	case OrderTypeLimit:
+	case OrderTypeMarket:
+		if params.TimeInForce != TimeInForceIOC && params.TimeInForce != TimeInForceFOK {
+			return fmt.Errorf("market orders must be IOC or FOK, got %q", params.TimeInForce)
+		}
+		if params.PostOnly {
+			return fmt.Errorf("market orders cannot be post-only")
+		}
+	case OrderTypeConditional:
+		if params.Trigger == nil {
+			return fmt.Errorf("conditional orders require a trigger")
+		}
+	case OrderTypeTpsl:
+		if params.TakeProfit == nil && params.StopLoss == nil {
+			return fmt.Errorf("tpsl orders require a take profit or stop loss")
+		}
+	default:
+		return fmt.Errorf("unsupported order type %q", params.OrderType)
+	}
+
+	if params.Trigger != nil && params.OrderType != OrderTypeConditional {
+		return fmt.Errorf("trigger is only allowed on conditional orders")
+	}
+	if (params.TakeProfit != nil || params.StopLoss != nil) && params.TpSlType == nil {
+		return fmt.Errorf("tpSlType must be set when take profit or stop loss is provided")
+	}
+	return nil
+}
+
+// roundOrderToMarket rounds the order and its protective legs to the market tick size and size step
+func roundOrderToMarket(params *CreateOrderObjectParams) {
 	market := params.Market
+	params.SyntheticAmount = market.RoundQty(params.SyntheticAmount)
+	params.Price = market.RoundPrice(params.Price, params.Side)
 
-	if params.ExpireTime == nil {
-		cur := time.Now().Add(1 * time.Hour)
-		params.ExpireTime = &cur
+	if params.Trigger != nil {
+		trigger := *params.Trigger
+		trigger.TriggerPrice = market.RoundPrice(trigger.TriggerPrice, params.Side)
+		params.Trigger = &trigger
 	}
 
-	// Error if nonce is nil, we keep the input as a pointer so that
-	// it is the same as the input to the function
-	if params.Nonce == nil {
-		return nil, fmt.Errorf("nonce must be provided")
+	// Protective legs close the position, so they are rounded for the closing side
+	closingSide := tpSlSide(*params)
+	roundLeg := func(leg *TpSlTriggerParams) *TpSlTriggerParams {
+		if leg == nil {
+			return nil
+		}
+		rounded := *leg
+		rounded.TriggerPrice = market.RoundPrice(rounded.TriggerPrice, closingSide)
+		rounded.Price = market.RoundPrice(rounded.Price, closingSide)
+		return &rounded
 	}
+	params.TakeProfit = roundLeg(params.TakeProfit)
+	params.StopLoss = roundLeg(params.StopLoss)
+}
 
-	// If we are buying, then we round up, otherwise we round down
-	is_buying_synthetic := params.Side == OrderSideBuy
-	collateral_amount := params.SyntheticAmount.Mul(params.Price)
+// validateOrderToMarket checks the order, its trigger and its protective legs against the market
+func validateOrderToMarket(params CreateOrderObjectParams) error {
+	market := params.Market
+	if err := market.ValidateOrder(params.OrderType, params.Side, params.SyntheticAmount, params.Price); err != nil {
+		return err
+	}
+	if params.MarkPrice != nil {
+		if err := market.ValidatePriceBand(params.Side, params.Price, *params.MarkPrice); err != nil {
+			return err
+		}
+	}
 
-	// For now we only use the default fee type
-	// TODO: Allow users to add different fee types
-	fees := DefaultFees
+	if params.Trigger != nil {
+		if err := market.ValidatePriceTick(params.Trigger.TriggerPrice); err != nil {
+			return err
+		}
+	}
+	for _, leg := range []*TpSlTriggerParams{params.TakeProfit, params.StopLoss} {
+		if leg == nil {
+			continue
+		}
+		if err := market.ValidatePriceTick(leg.TriggerPrice); err != nil {
+			return err
+		}
+		if err := market.ValidatePriceTick(leg.Price); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createSettlement computes the stark amounts for one side of an order, hashes and signs them
+func createSettlement(params CreateOrderObjectParams, settlementParams orderSettlementParams) (Settlement, string, error) {
+	market := params.Market
 
-	total_fee := fees.TakerFeeRate
+	// If we are buying, then we round up, otherwise we round down
+	is_buying_synthetic := settlementParams.Side == OrderSideBuy
+	collateral_amount := settlementParams.SyntheticAmount.Mul(settlementParams.Price)
+
+	total_fee := settlementParams.FeeRate
 	if params.BuilderFee != nil {
 		total_fee = total_fee.Add(*params.BuilderFee)
 	}
@@ -176,7 +353,7 @@ func CreateOrderObject(params CreateOrderObjectParams) (*PerpetualOrderModel, er
 	fee_amount := total_fee.Mul(collateral_amount)
 
 	stark_collateral_amount_dec := collateral_amount.Mul(decimal.NewFromInt(market.L2Config.CollateralResolution))
-	stark_synthetic_amount_dec := params.SyntheticAmount.Mul(decimal.NewFromInt(market.L2Config.SyntheticResolution))
+	stark_synthetic_amount_dec := settlementParams.SyntheticAmount.Mul(decimal.NewFromInt(market.L2Config.SyntheticResolution))
 
 	// Round accordingly
 	if is_buying_synthetic {
@@ -211,12 +388,12 @@ func CreateOrderObject(params CreateOrderObjectParams) (*PerpetualOrderModel, er
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("hashing order failed: %w", err)
+		return Settlement{}, "", fmt.Errorf("hashing order failed: %w", err)
 	}
 
 	sig_r, sig_s, err := params.Signer(order_hash)
 	if err != nil {
-		return nil, fmt.Errorf("signer function failed: %w", err)
+		return Settlement{}, "", fmt.Errorf("signer function failed: %w", err)
 	}
 
 	settlement := Settlement{
@@ -228,6 +405,112 @@ func CreateOrderObject(params CreateOrderObjectParams) (*PerpetualOrderModel, er
 		CollateralPosition: fmt.Sprintf("%d", params.Account.Vault()),
 	}
 
+	return settlement, order_hash, nil
+}
+
+// createTpSlTrigger signs a take profit or stop loss leg, which closes the position
+// opened by the parent order and therefore trades on the opposite side
+func createTpSlTrigger(params CreateOrderObjectParams, leg *TpSlTriggerParams, fee_rate decimal.Decimal) (*TpSlTrigger, error) {
+	if leg == nil {
+		return nil, nil
+	}
+
+	settlement, _, err := createSettlement(params, orderSettlementParams{
+		Side:            tpSlSide(params),
+		SyntheticAmount: params.SyntheticAmount,
+		Price:           leg.Price,
+		FeeRate:         fee_rate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TpSlTrigger{
+		TriggerPrice:     leg.TriggerPrice.String(),
+		TriggerPriceType: leg.TriggerPriceType,
+		Price:            leg.Price.String(),
+		PriceType:        leg.PriceType,
+		Settlement:       settlement,
+	}, nil
+}
+
+// CreateOrderObject creates a PerpetualOrderModel with the given parameters
+func CreateOrderObject(params CreateOrderObjectParams) (*PerpetualOrderModel, error) {
+	if params.ExpireTime == nil {
+		cur := time.Now().Add(1 * time.Hour)
+		params.ExpireTime = &cur
+	}
+
+	// Error if nonce is nil, we keep the input as a pointer so that
+	// it is the same as the input to the function
+	if params.Nonce == nil {
+		return nil, fmt.Errorf("nonce must be provided")
+	}
+
+	if params.StarknetDomain == (StarknetDomain{}) {
+		return nil, fmt.Errorf("starknet domain must be provided, e.g. from TestnetConfig().StarknetDomain")
+	}
+
+	if params.OrderType == "" {
+		params.OrderType = OrderTypeLimit
+	}
+	if err := validateOrderType(params); err != nil {
+		return nil, err
+	}
+
+	if params.RoundToMarket {
+		roundOrderToMarket(&params)
+	}
+	if params.Validate {
+		if err := validateOrderToMarket(params); err != nil {
+			return nil, err
+		}
+	}
+
+	fees := DefaultFees
+	if params.Fees != nil {
+		if params.Fees.Market != "" && params.Fees.Market != params.Market.Name {
+			return nil, fmt.Errorf("fees are for market %s, not %s", params.Fees.Market, params.Market.Name)
+		}
+		fees = *params.Fees
+	}
+
+	// Post-only orders can only ever rest on the book, so they pay the maker rate
+	fee_rate := fees.TakerFeeRate
+	if params.PostOnly {
+		fee_rate = fees.MakerFeeRate
+	}
+
+	settlement, order_hash, err := createSettlement(params, orderSettlementParams{
+		Side:            params.Side,
+		SyntheticAmount: params.SyntheticAmount,
+		Price:           params.Price,
+		FeeRate:         fee_rate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Protective legs execute against the book, so they are always charged the taker rate
+	take_profit, err := createTpSlTrigger(params, params.TakeProfit, fees.TakerFeeRate)
+	if err != nil {
+		return nil, fmt.Errorf("take profit: %w", err)
+	}
+	stop_loss, err := createTpSlTrigger(params, params.StopLoss, fees.TakerFeeRate)
+	if err != nil {
+		return nil, fmt.Errorf("stop loss: %w", err)
+	}
+
+	var trigger *ConditionalTrigger
+	if params.Trigger != nil {
+		trigger = &ConditionalTrigger{
+			TriggerPrice:       params.Trigger.TriggerPrice.String(),
+			TriggerPriceType:   params.Trigger.TriggerPriceType,
+			Direction:          params.Trigger.Direction,
+			ExecutionPriceType: params.Trigger.ExecutionPriceType,
+		}
+	}
+
 	if params.OrderExternalID == nil {
 		defaultID := order_hash
 		params.OrderExternalID = &defaultID
@@ -245,20 +528,26 @@ func CreateOrderObject(params CreateOrderObjectParams) (*PerpetualOrderModel, er
 	order := &PerpetualOrderModel{
 		ID:                       *params.OrderExternalID,
 		Market:                   params.Market.Name,
-		Type:                     OrderTypeLimit,
+		Type:                     params.OrderType,
 		Side:                     params.Side,
 		Qty:                      params.SyntheticAmount.String(),
 		Price:                    params.Price.String(),
 		PostOnly:                 params.PostOnly,
+		ReduceOnly:               params.ReduceOnly,
 		TimeInForce:              params.TimeInForce,
 		ExpiryEpochMillis:        expiryEpochMillis,
-		Fee:                      fees.TakerFeeRate.String(),
+		Fee:                      fee_rate.String(),
 		SelfTradeProtectionLevel: params.SelfTradeProtectionLevel,
 		Nonce:                    fmt.Sprintf("%d", *params.Nonce),
 		CancelID:                 params.PreviousOrderExternalID,
 		Settlement:               settlement,
+		Trigger:                  trigger,
+		TpSlType:                 params.TpSlType,
+		TakeProfit:               take_profit,
+		StopLoss:                 stop_loss,
 		BuilderFee:               fee_builder_str,
 		BuilderID:                params.BuilderID,
+		domain:                   params.StarknetDomain,
 	}
 
 	return order, nil
@@ -316,3 +605,53 @@ func HashOrder(params HashOrderParams) (string, error) {
 
 	return hash, nil
 }
+
+// OrderStatus represents the lifecycle state of an order on the exchange
+type OrderStatus string
+
+const (
+	OrderStatusNew             OrderStatus = "NEW"
+	OrderStatusPartiallyFilled OrderStatus = "PARTIALLY_FILLED"
+	OrderStatusFilled          OrderStatus = "FILLED"
+	OrderStatusUntriggered     OrderStatus = "UNTRIGGERED"
+	OrderStatusTriggered       OrderStatus = "TRIGGERED"
+	OrderStatusCancelled       OrderStatus = "CANCELLED"
+	OrderStatusRejected        OrderStatus = "REJECTED"
+	OrderStatusExpired         OrderStatus = "EXPIRED"
+)
+
+// OrderTpSlTriggerModel represents a take profit or stop loss leg as reported by the exchange
+type OrderTpSlTriggerModel struct {
+	TriggerPrice     decimal.Decimal    `json:"triggerPrice"`
+	TriggerPriceType TriggerPriceType   `json:"triggerPriceType"`
+	Price            decimal.Decimal    `json:"price"`
+	PriceType        ExecutionPriceType `json:"priceType"`
+	Status           OrderStatus        `json:"status,omitempty"`
+}
+
+// OrderModel represents an order as reported by the exchange
+type OrderModel struct {
+	ID                uint                   `json:"id"`
+	AccountID         uint                   `json:"accountId"`
+	ExternalID        string                 `json:"externalId"`
+	Market            string                 `json:"market"`
+	Type              OrderType              `json:"type"`
+	Side              OrderSide              `json:"side"`
+	Status            OrderStatus            `json:"status"`
+	StatusReason      string                 `json:"statusReason,omitempty"`
+	Price             decimal.Decimal        `json:"price"`
+	AveragePrice      decimal.Decimal        `json:"averagePrice"`
+	Qty               decimal.Decimal        `json:"qty"`
+	FilledQty         decimal.Decimal        `json:"filledQty"`
+	PayedFee          decimal.Decimal        `json:"payedFee"`
+	ReduceOnly        bool                   `json:"reduceOnly"`
+	PostOnly          bool                   `json:"postOnly"`
+	TimeInForce       TimeInForce            `json:"timeInForce"`
+	Trigger           *ConditionalTrigger    `json:"trigger,omitempty"`
+	TpSlType          *TpSlType              `json:"tpSlType,omitempty"`
+	TakeProfit        *OrderTpSlTriggerModel `json:"takeProfit,omitempty"`
+	StopLoss          *OrderTpSlTriggerModel `json:"stopLoss,omitempty"`
+	CreatedTime       int64                  `json:"createdTime"`
+	UpdatedTime       int64                  `json:"updatedTime"`
+	ExpiryEpochMillis int64                  `json:"expireTime"`
+}
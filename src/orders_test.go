@@ -1,7 +1,11 @@
 package sdk
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -421,6 +425,278 @@ func (suite *OrdersTestSuite) TestExternalOrderID() {
 	suite.Equal(customOrderID, actualOrder["id"])
 }
 
+func (suite *OrdersTestSuite) TestCustomFees() {
+	fees := TradingFeeModel{
+		Market:         suite.market.Name,
+		MakerFeeRate:   decimal.RequireFromString("0.0001"),
+		TakerFeeRate:   decimal.RequireFromString("0.0003"),
+		BuilderFeeRate: decimal.Zero,
+	}
+
+	params := suite.baseOrderParams()
+	params.Fees = &fees
+
+	order, err := CreateOrderObject(params)
+	suite.Require().NoError(err)
+	suite.Equal("0.0003", order.Fee, "Taker orders should use the taker rate")
+
+	params.PostOnly = true
+	postOnlyOrder, err := CreateOrderObject(params)
+	suite.Require().NoError(err)
+	suite.Equal("0.0001", postOnlyOrder.Fee, "Post-only orders should use the maker rate")
+	suite.NotEqual(order.ID, postOnlyOrder.ID, "Max fee is part of the signed hash")
+
+	otherMarketFees := fees
+	otherMarketFees.Market = "ETH-USD"
+	params.Fees = &otherMarketFees
+	_, err = CreateOrderObject(params)
+	suite.Error(err, "Fees for another market should be rejected")
+}
+
+func (suite *OrdersTestSuite) baseOrderParams() CreateOrderObjectParams {
+	expiryTime := suite.frozenTime.Add(1 * time.Hour)
+	return CreateOrderObjectParams{
+		Market:                   suite.market,
+		Account:                  *suite.account,
+		SyntheticAmount:          decimal.RequireFromString("0.00100000"),
+		Price:                    decimal.RequireFromString("43445.11680000"),
+		Side:                     OrderSideBuy,
+		Signer:                   suite.account.Sign,
+		StarknetDomain:           suite.starknetDomain,
+		ExpireTime:               &expiryTime,
+		TimeInForce:              TimeInForceGTT,
+		SelfTradeProtectionLevel: SelfTradeProtectionAccount,
+		Nonce:                    &suite.nonce,
+	}
+}
+
+func (suite *OrdersTestSuite) TestCreateMarketOrder() {
+	order, err := CreateMarketOrderObject(suite.baseOrderParams(), decimal.RequireFromString("40000"), decimal.RequireFromString("0.01"))
+	suite.Require().NoError(err)
+
+	suite.Equal(OrderTypeMarket, order.Type)
+	suite.Equal(TimeInForceIOC, order.TimeInForce)
+	suite.Equal("40400", order.Price, "Buy price should be bounded above the reference price")
+
+	params := suite.baseOrderParams()
+	params.Side = OrderSideSell
+	order, err = CreateMarketOrderObject(params, decimal.RequireFromString("40000"), decimal.RequireFromString("0.01"))
+	suite.Require().NoError(err)
+	suite.Equal("39600", order.Price, "Sell price should be bounded below the reference price")
+
+	params = suite.baseOrderParams()
+	params.OrderType = OrderTypeMarket
+	_, err = CreateOrderObject(params)
+	suite.Error(err, "GTT market orders should be rejected")
+}
+
+func (suite *OrdersTestSuite) TestCreateConditionalOrder() {
+	order, err := CreateConditionalOrderObject(suite.baseOrderParams(), ConditionalTriggerParams{
+		TriggerPrice:       decimal.RequireFromString("44000"),
+		TriggerPriceType:   TriggerPriceTypeMark,
+		Direction:          TriggerDirectionUp,
+		ExecutionPriceType: ExecutionPriceTypeLimit,
+	})
+	suite.Require().NoError(err)
+
+	suite.Equal(OrderTypeConditional, order.Type)
+	suite.Require().NotNil(order.Trigger)
+	suite.Equal(ConditionalTrigger{
+		TriggerPrice:       "44000",
+		TriggerPriceType:   TriggerPriceTypeMark,
+		Direction:          TriggerDirectionUp,
+		ExecutionPriceType: ExecutionPriceTypeLimit,
+	}, *order.Trigger)
+
+	params := suite.baseOrderParams()
+	params.OrderType = OrderTypeConditional
+	_, err = CreateOrderObject(params)
+	suite.Error(err, "Conditional orders without a trigger should be rejected")
+}
+
+func (suite *OrdersTestSuite) TestCreateOrderWithTpSl() {
+	tpSlType := TpSlTypeOrder
+	params := suite.baseOrderParams()
+	params.TpSlType = &tpSlType
+	params.TakeProfit = &TpSlTriggerParams{
+		TriggerPrice:     decimal.RequireFromString("45000"),
+		TriggerPriceType: TriggerPriceTypeLast,
+		Price:            decimal.RequireFromString("44900"),
+		PriceType:        ExecutionPriceTypeLimit,
+	}
+	params.StopLoss = &TpSlTriggerParams{
+		TriggerPrice:     decimal.RequireFromString("42000"),
+		TriggerPriceType: TriggerPriceTypeMark,
+		Price:            decimal.RequireFromString("41900"),
+		PriceType:        ExecutionPriceTypeMarket,
+	}
+
+	order, err := CreateOrderObject(params)
+	suite.Require().NoError(err)
+
+	suite.Equal(OrderTypeLimit, order.Type)
+	suite.Equal(&tpSlType, order.TpSlType)
+	suite.Require().NotNil(order.TakeProfit)
+	suite.Require().NotNil(order.StopLoss)
+	suite.Equal("45000", order.TakeProfit.TriggerPrice)
+	suite.Equal("44900", order.TakeProfit.Price)
+	suite.Equal(ExecutionPriceTypeMarket, order.StopLoss.PriceType)
+
+	// Each leg is signed on its own, closing the buy with a sell
+	closing, err := CreateOrderObject(CreateOrderObjectParams{
+		Market:          params.Market,
+		Account:         params.Account,
+		SyntheticAmount: params.SyntheticAmount,
+		Price:           params.TakeProfit.Price,
+		Side:            OrderSideSell,
+		Signer:          params.Signer,
+		StarknetDomain:  params.StarknetDomain,
+		ExpireTime:      params.ExpireTime,
+		TimeInForce:     TimeInForceGTT,
+		Nonce:           params.Nonce,
+	})
+	suite.Require().NoError(err)
+	suite.Equal(closing.Settlement, order.TakeProfit.Settlement)
+	suite.NotEqual(order.Settlement, order.StopLoss.Settlement)
+
+	params.TpSlType = nil
+	_, err = CreateOrderObject(params)
+	suite.Error(err, "Legs without a tpSlType should be rejected")
+}
+
+func (suite *OrdersTestSuite) TestCreateTpSlOrder() {
+	params := suite.baseOrderParams()
+	params.Side = OrderSideSell
+	order, err := CreateTpSlOrderObject(params, TpSlTypePosition, nil, &TpSlTriggerParams{
+		TriggerPrice:     decimal.RequireFromString("42000"),
+		TriggerPriceType: TriggerPriceTypeMark,
+		Price:            decimal.RequireFromString("41900"),
+		PriceType:        ExecutionPriceTypeMarket,
+	})
+	suite.Require().NoError(err)
+
+	suite.Equal(OrderTypeTpsl, order.Type)
+	suite.True(order.ReduceOnly)
+	suite.Nil(order.TakeProfit)
+	suite.Require().NotNil(order.StopLoss)
+
+	_, err = CreateTpSlOrderObject(params, TpSlTypePosition, nil, nil)
+	suite.Error(err, "TPSL orders without legs should be rejected")
+}
+
+func (suite *OrdersTestSuite) TestRoundToMarket() {
+	suite.market.TradingConfig = TradingConfigModel{
+		MinOrderSize:       decimal.RequireFromString("0.0001"),
+		MinOrderSizeChange: decimal.RequireFromString("0.0001"),
+		MinPriceChange:     decimal.RequireFromString("0.5"),
+	}
+
+	params := suite.baseOrderParams()
+	params.SyntheticAmount = decimal.RequireFromString("0.00123")
+	params.Price = decimal.RequireFromString("43445.9")
+
+	_, err := CreateOrderObject(params)
+	suite.Require().NoError(err, "Orders are only validated on request")
+
+	params.Validate = true
+	_, err = CreateOrderObject(params)
+	suite.ErrorIs(err, ErrInvalidQtyStep, "Unrounded orders should fail validation")
+
+	params.RoundToMarket = true
+	order, err := CreateOrderObject(params)
+	suite.Require().NoError(err)
+	suite.Equal("0.0012", order.Qty)
+	suite.Equal("43445.5", order.Price, "Buy prices should be rounded down")
+
+	params.Side = OrderSideSell
+	order, err = CreateOrderObject(params)
+	suite.Require().NoError(err)
+	suite.Equal("43446", order.Price, "Sell prices should be rounded up")
+
+	params.SyntheticAmount = decimal.RequireFromString("0.00005")
+	_, err = CreateOrderObject(params)
+	suite.ErrorIs(err, ErrOrderBelowMinSize)
+}
+
+func (suite *OrdersTestSuite) TestValidateOrder() {
+	suite.market.TradingConfig = TradingConfigModel{
+		MinPriceChange:  decimal.RequireFromString("0.5"),
+		LimitPriceCap:   decimal.RequireFromString("0.05"),
+		LimitPriceFloor: decimal.RequireFromString("0.05"),
+	}
+	suite.market.MarketStats.MarkPrice = decimal.RequireFromString("10000")
+
+	params := suite.baseOrderParams()
+	params.Price = decimal.RequireFromString("43445.5")
+	params.Validate = true
+	_, err := CreateOrderObject(params)
+	suite.Require().NoError(err, "The band is not checked against the cached mark price")
+
+	markPrice := decimal.RequireFromString("40000")
+	params.MarkPrice = &markPrice
+	_, err = CreateOrderObject(params)
+	suite.ErrorIs(err, ErrPriceOutsideBand)
+
+	params.MarkPrice = nil
+	tpSlType := TpSlTypeOrder
+	params.TpSlType = &tpSlType
+	params.StopLoss = &TpSlTriggerParams{
+		TriggerPrice:     decimal.RequireFromString("42000.2"),
+		TriggerPriceType: TriggerPriceTypeMark,
+		Price:            decimal.RequireFromString("41900"),
+		PriceType:        ExecutionPriceTypeLimit,
+	}
+	_, err = CreateOrderObject(params)
+	suite.ErrorIs(err, ErrInvalidPriceTick, "Leg prices should be checked against the tick size")
+
+	params.RoundToMarket = true
+	_, err = CreateOrderObject(params)
+	suite.Require().NoError(err)
+}
+
+func (suite *OrdersTestSuite) TestRequiresStarknetDomain() {
+	suite.Equal(suite.starknetDomain, TestnetConfig().StarknetDomain)
+
+	params := suite.baseOrderParams()
+	params.StarknetDomain = StarknetDomain{}
+	_, err := CreateOrderObject(params)
+	suite.Error(err, "Orders without a domain cannot be signed correctly")
+}
+
+func (suite *OrdersTestSuite) TestClientOrderDomain() {
+	var submitted atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		submitted.Add(1)
+		w.Write([]byte(`{"status":"OK","data":{"id":1,"externalId":"ext-1"}}`))
+	}))
+	defer server.Close()
+	cfg := TestnetConfig()
+	cfg.APIBaseURL = server.URL
+	client := NewClient(cfg, WithAPIKey(TestAPIKey))
+
+	params := suite.baseOrderParams()
+	params.StarknetDomain = StarknetDomain{}
+	externalID := "ext-1"
+	params.OrderExternalID = &externalID
+	order, err := client.CreateOrder(params)
+	suite.Require().NoError(err)
+	expected, err := CreateOrderObject(suite.baseOrderParams())
+	suite.Require().NoError(err)
+	suite.Equal(expected.Settlement, order.Settlement, "Orders should be signed for the client's domain")
+	_, err = client.SubmitOrder(context.Background(), order)
+	suite.Require().NoError(err)
+
+	params.StarknetDomain = MainnetConfig().StarknetDomain
+	_, err = client.CreateOrder(params)
+	suite.Error(err, "Orders for another domain should be rejected")
+
+	mainnetOrder, err := CreateOrderObject(params)
+	suite.Require().NoError(err)
+	_, err = client.SubmitOrder(context.Background(), mainnetOrder)
+	suite.ErrorContains(err, "SN_MAIN")
+	suite.Equal(int32(1), submitted.Load(), "Orders for another domain should not be sent")
+}
+
 // TestOrdersTestSuite runs the test suite
 func TestOrdersTestSuite(t *testing.T) {
 	suite.Run(t, new(OrdersTestSuite))
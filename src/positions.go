@@ -0,0 +1,59 @@
+package sdk
+
+import "github.com/shopspring/decimal"
+
+type PositionSide string
+
+const (
+	PositionSideLong  PositionSide = "LONG"
+	PositionSideShort PositionSide = "SHORT"
+)
+
+// PositionModel represents an open position
+type PositionModel struct {
+	ID               uint            `json:"id"`
+	AccountID        uint            `json:"accountId"`
+	Market           string          `json:"market"`
+	Side             PositionSide    `json:"side"`
+	Leverage         decimal.Decimal `json:"leverage"`
+	Size             decimal.Decimal `json:"size"`
+	Value            decimal.Decimal `json:"value"`
+	OpenPrice        decimal.Decimal `json:"openPrice"`
+	MarkPrice        decimal.Decimal `json:"markPrice"`
+	LiquidationPrice decimal.Decimal `json:"liquidationPrice"`
+	Margin           decimal.Decimal `json:"margin"`
+	UnrealisedPnl    decimal.Decimal `json:"unrealisedPnl"`
+	RealisedPnl      decimal.Decimal `json:"realisedPnl"`
+	TpTriggerPrice   decimal.Decimal `json:"tpTriggerPrice"`
+	TpLimitPrice     decimal.Decimal `json:"tpLimitPrice"`
+	SlTriggerPrice   decimal.Decimal `json:"slTriggerPrice"`
+	SlLimitPrice     decimal.Decimal `json:"slLimitPrice"`
+	Adl              int             `json:"adl"`
+	CreatedAt        int64           `json:"createdAt"`
+	UpdatedAt        int64           `json:"updatedAt"`
+}
+
+// PositionExitType describes how a closed position was exited
+type PositionExitType string
+
+const (
+	PositionExitTypeTrade       PositionExitType = "TRADE"
+	PositionExitTypeLiquidation PositionExitType = "LIQUIDATION"
+	PositionExitTypeADL         PositionExitType = "ADL"
+)
+
+// PositionHistoryModel represents a closed or partially closed position
+type PositionHistoryModel struct {
+	ID          uint             `json:"id"`
+	AccountID   uint             `json:"accountId"`
+	Market      string           `json:"market"`
+	Side        PositionSide     `json:"side"`
+	Leverage    decimal.Decimal  `json:"leverage"`
+	Size        decimal.Decimal  `json:"size"`
+	OpenPrice   decimal.Decimal  `json:"openPrice"`
+	ExitType    PositionExitType `json:"exitType,omitempty"`
+	ExitPrice   decimal.Decimal  `json:"exitPrice"`
+	RealisedPnl decimal.Decimal  `json:"realisedPnl"`
+	CreatedTime int64            `json:"createdTime"`
+	ClosedTime  int64            `json:"closedTime"`
+}
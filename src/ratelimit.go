@@ -0,0 +1,177 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit configures a token bucket: Rate requests per second on average, with bursts of up
+// to Burst requests. A zero Rate disables the limit; with a positive Rate, Burst is at least 1.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// Default rate limits. Together they stay below the exchange's limit of 1,000 requests per minute.
+var (
+	DefaultMarketDataRateLimit = RateLimit{Rate: 8, Burst: 20}
+	DefaultTradingRateLimit    = RateLimit{Rate: 8, Burst: 20}
+)
+
+// Rate limit response headers
+const (
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	rateLimitResetHeader     = "X-RateLimit-Reset"
+	retryAfterHeader         = "Retry-After"
+)
+
+// tokenBucket limits the request rate of one group of endpoints. Besides the configured rate,
+// it holds requests back while the exchange reports the limit as exhausted.
+type tokenBucket struct {
+	mu           sync.Mutex
+	limit        RateLimit
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	// A bucket that cannot hold a whole token would never let a request through
+	if limit.Rate > 0 && limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	return &tokenBucket{limit: limit, tokens: float64(limit.Burst), last: time.Now()}
+}
+
+// reserve takes a token if one is available, or returns how long to wait before trying again
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.Before(b.blockedUntil) {
+		return b.blockedUntil.Sub(now)
+	}
+	if b.limit.Rate <= 0 {
+		return 0
+	}
+
+	b.tokens = math.Min(float64(b.limit.Burst), b.tokens+now.Sub(b.last).Seconds()*b.limit.Rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.limit.Rate * float64(time.Second))
+}
+
+// wait blocks until a request may be sent. If the wait would outlast the context's deadline,
+// it fails immediately with ErrRateLimited instead.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		delay := b.reserve(time.Now())
+		if delay <= 0 {
+			return nil
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			return fmt.Errorf("%w: next request slot in %s is past the context deadline", ErrRateLimited, delay.Round(time.Millisecond))
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// block holds requests back until the given time
+func (b *tokenBucket) block(until time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if until.After(b.blockedUntil) {
+		b.blockedUntil = until
+	}
+}
+
+// observe adapts the bucket to the limits reported in a response
+func (b *tokenBucket) observe(resp *http.Response, now time.Time) {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get(retryAfterHeader), now); ok {
+			b.block(now.Add(retryAfter))
+		}
+	}
+
+	remaining, err := strconv.Atoi(resp.Header.Get(rateLimitRemainingHeader))
+	if err != nil {
+		return
+	}
+	b.mu.Lock()
+	b.tokens = math.Min(b.tokens, float64(remaining))
+	b.mu.Unlock()
+
+	if remaining <= 0 {
+		if reset, ok := parseRateLimitReset(resp.Header.Get(rateLimitResetHeader), now); ok {
+			b.block(reset)
+		}
+	}
+}
+
+// parseRetryAfter parses a Retry-After value given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(math.Max(seconds, 0) * float64(time.Second)), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// parseRateLimitReset parses a reset header given either as seconds from now or as an epoch
+// timestamp in seconds or milliseconds
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	reset, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || reset < 0 {
+		return time.Time{}, false
+	}
+	switch {
+	case reset > 1e12:
+		return time.UnixMilli(reset), true
+	case reset > 1e9:
+		return time.Unix(reset, 0), true
+	default:
+		return now.Add(time.Duration(reset) * time.Second), true
+	}
+}
+
+// rateLimiter routes requests to separate buckets for public market data and private trading endpoints
+type rateLimiter struct {
+	marketData *tokenBucket
+	trading    *tokenBucket
+}
+
+func newRateLimiter(marketData, trading RateLimit) *rateLimiter {
+	return &rateLimiter{
+		marketData: newTokenBucket(marketData),
+		trading:    newTokenBucket(trading),
+	}
+}
+
+func (l *rateLimiter) bucketFor(rawURL string) *tokenBucket {
+	if strings.Contains(rawURL, "/info/") {
+		return l.marketData
+	}
+	return l.trading
+}
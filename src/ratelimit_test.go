@@ -0,0 +1,115 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucket_RefillsAtRate(t *testing.T) {
+	bucket := newTokenBucket(RateLimit{Rate: 10, Burst: 2})
+	now := bucket.last
+
+	assert.Zero(t, bucket.reserve(now))
+	assert.Zero(t, bucket.reserve(now))
+	assert.Equal(t, 100*time.Millisecond, bucket.reserve(now), "An empty bucket should wait for the next token")
+	assert.Zero(t, bucket.reserve(now.Add(100*time.Millisecond)))
+
+	noBurst := newTokenBucket(RateLimit{Rate: 100})
+	start := noBurst.last
+	assert.Zero(t, noBurst.reserve(start), "A rate without a burst should still admit one request at a time")
+	assert.Equal(t, 10*time.Millisecond, noBurst.reserve(start))
+	assert.Zero(t, noBurst.reserve(start.Add(10*time.Millisecond)))
+
+	unlimited := newTokenBucket(RateLimit{})
+	for range 100 {
+		assert.Zero(t, unlimited.reserve(now))
+	}
+}
+
+func TestTokenBucket_WaitBlocksOrFailsFast(t *testing.T) {
+	bucket := newTokenBucket(RateLimit{Rate: 20, Burst: 1})
+	require.NoError(t, bucket.wait(context.Background()))
+
+	// The next token is 50ms away, past this deadline
+	short, cancelShort := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancelShort()
+	assert.ErrorIs(t, bucket.wait(short), ErrRateLimited, "The second request should have to wait for a token")
+	require.NoError(t, bucket.wait(context.Background()))
+
+	// Waiting out the deadline would end in context.DeadlineExceeded instead
+	bucket.block(time.Now().Add(time.Hour))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := bucket.wait(ctx)
+	assert.ErrorIs(t, err, ErrRateLimited, "A wait past the deadline should fail immediately")
+	assert.NotErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestTokenBucket_ObserveHeaders(t *testing.T) {
+	now := time.Now()
+	response := func(status int, headers map[string]string) *http.Response {
+		resp := &http.Response{StatusCode: status, Header: http.Header{}}
+		for k, v := range headers {
+			resp.Header.Set(k, v)
+		}
+		return resp
+	}
+
+	bucket := newTokenBucket(RateLimit{})
+	bucket.observe(response(http.StatusTooManyRequests, map[string]string{"Retry-After": "2"}), now)
+	assert.Equal(t, 2*time.Second, bucket.reserve(now))
+
+	bucket = newTokenBucket(RateLimit{})
+	bucket.observe(response(http.StatusTooManyRequests, map[string]string{"Retry-After": now.Add(3 * time.Second).UTC().Format(http.TimeFormat)}), now)
+	assert.InDelta(t, 3*time.Second, bucket.reserve(now), float64(time.Second))
+
+	bucket = newTokenBucket(RateLimit{})
+	bucket.observe(response(http.StatusOK, map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "5"}), now)
+	assert.Equal(t, 5*time.Second, bucket.reserve(now))
+
+	bucket = newTokenBucket(RateLimit{})
+	reset := now.Add(4 * time.Second).Truncate(time.Millisecond)
+	bucket.observe(response(http.StatusOK, map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": strconv.FormatInt(reset.UnixMilli(), 10)}), now)
+	assert.Equal(t, reset.Sub(now), bucket.reserve(now))
+
+	bucket = newTokenBucket(RateLimit{Rate: 1, Burst: 10})
+	bucket.observe(response(http.StatusOK, map[string]string{"X-RateLimit-Remaining": "1"}), bucket.last)
+	assert.Zero(t, bucket.reserve(bucket.last))
+	assert.Positive(t, bucket.reserve(bucket.last), "Remaining should cap the available tokens")
+}
+
+func TestDoRequest_HonorsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	_, err := client.GetBalance(ctx)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.NoError(t, ctx.Err(), "Retrying after 30s cannot fit the deadline, so the call should fail without waiting for it")
+	assert.Equal(t, int32(1), calls.Load())
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 30*time.Second, apiErr.RetryAfter)
+
+	// Trading endpoints are blocked, market data endpoints are not
+	_, err = client.GetPositions(ctx, nil, nil)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, int32(1), calls.Load())
+
+	client.GetOrderbook(ctx, "BTC-USD")
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -0,0 +1,108 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// RetryPolicy controls how DoRequest repeats failed requests. GET, HEAD, OPTIONS and DELETE
+// requests are retried freely; other methods only when the request was marked idempotent,
+// as SubmitOrder does for orders carrying an external ID.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per request. Values below 2 disable retries.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// RetryableStatuses are the HTTP statuses worth repeating a request for.
+	// Network errors are always retryable.
+	RetryableStatuses []int
+	// OnAttempt, if set, is called after every attempt
+	OnAttempt func(RequestAttempt)
+}
+
+// RequestAttempt describes the outcome of a single attempt of a request
+type RequestAttempt struct {
+	Method  string
+	URL     string
+	Attempt int
+	// StatusCode is 0 when no response was received
+	StatusCode int
+	Err        error
+	// Retry is set when another attempt follows after Delay
+	Retry bool
+	Delay time.Duration
+}
+
+// DefaultRetryPolicy makes up to 3 attempts on rate limits, server errors and network errors
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 200 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+	RetryableStatuses: []int{
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	},
+}
+
+// NoRetryPolicy makes a single attempt per request
+var NoRetryPolicy = RetryPolicy{MaxAttempts: 1}
+
+// networkError marks failures where no complete response was received
+type networkError struct {
+	err error
+}
+
+func (e *networkError) Error() string { return e.err.Error() }
+func (e *networkError) Unwrap() error { return e.err }
+
+func (p RetryPolicy) shouldRetry(statusCode int, err error) bool {
+	var netErr *networkError
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && slices.Contains(p.RetryableStatuses, statusCode)
+}
+
+// jitteredBackoff returns initial * multiplier^(attempt-1) capped at max, with up to half of it
+// replaced by random jitter
+func jitteredBackoff(initial, max time.Duration, multiplier float64, attempt int) time.Duration {
+	delay := float64(initial)
+	for i := 1; i < attempt && (max <= 0 || delay < float64(max)); i++ {
+		delay *= multiplier
+	}
+	if max > 0 && delay > float64(max) {
+		delay = float64(max)
+	}
+	half := time.Duration(delay / 2)
+	if half <= 0 {
+		return time.Duration(delay)
+	}
+	return half + rand.N(half)
+}
+
+type idempotentKey struct{}
+
+// withIdempotentRequest marks requests made with the returned context as safe to retry
+// regardless of their method
+func withIdempotentRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+func isIdempotentRequest(ctx context.Context, method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodDelete:
+		return true
+	}
+	marked, _ := ctx.Value(idempotentKey{}).(bool)
+	return marked
+}
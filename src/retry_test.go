@@ -0,0 +1,229 @@
+package sdk
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fastRetryPolicy(attempts *[]RequestAttempt) RetryPolicy {
+	policy := DefaultRetryPolicy
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	policy.OnAttempt = func(attempt RequestAttempt) {
+		*attempts = append(*attempts, attempt)
+	}
+	return policy
+}
+
+func TestDoRequest_RetriesGetOnRetryableStatus(t *testing.T) {
+	var calls atomic.Int32
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status":"OK","data":{"equity":"100"}}`))
+	})
+	var attempts []RequestAttempt
+	client.SetRetryPolicy(fastRetryPolicy(&attempts))
+
+	balance, err := client.GetBalance(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "100", balance.Equity.String())
+
+	require.Len(t, attempts, 3)
+	assert.Equal(t, http.StatusServiceUnavailable, attempts[0].StatusCode)
+	assert.True(t, attempts[0].Retry)
+	assert.ErrorIs(t, attempts[1].Err, ErrServerError)
+	assert.Equal(t, 3, attempts[2].Attempt)
+	assert.NoError(t, attempts[2].Err)
+	assert.False(t, attempts[2].Retry)
+}
+
+func TestDoRequest_StopsAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	var attempts []RequestAttempt
+	client.SetRetryPolicy(fastRetryPolicy(&attempts))
+
+	_, err := client.GetBalance(context.Background())
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, int32(3), calls.Load())
+	assert.False(t, attempts[len(attempts)-1].Retry)
+}
+
+func TestDoRequest_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	var attempts []RequestAttempt
+	client.SetRetryPolicy(fastRetryPolicy(&attempts))
+
+	_, err := client.GetBalance(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestDoRequest_RetriesNetworkErrors(t *testing.T) {
+	var calls atomic.Int32
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			// Drop the connection without responding
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if assert.NoError(t, err) {
+				conn.Close()
+			}
+			return
+		}
+		w.Write([]byte(`{"status":"OK","data":{"equity":"100"}}`))
+	})
+	var attempts []RequestAttempt
+	client.SetRetryPolicy(fastRetryPolicy(&attempts))
+
+	_, err := client.GetBalance(context.Background())
+	require.NoError(t, err)
+	require.Len(t, attempts, 2)
+	assert.Equal(t, 0, attempts[0].StatusCode)
+	assert.Error(t, attempts[0].Err)
+}
+
+// orderRecorder is a mock order endpoint that records every submitted body
+type orderRecorder struct {
+	mu     sync.Mutex
+	bodies []string
+}
+
+func (o *orderRecorder) record(t *testing.T, r *http.Request) int {
+	body, err := io.ReadAll(r.Body)
+	assert.NoError(t, err)
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.bodies = append(o.bodies, string(body))
+	return len(o.bodies)
+}
+
+func (o *orderRecorder) recorded() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return slices.Clone(o.bodies)
+}
+
+func TestDoRequest_RetriesOrderOnlyWithExternalID(t *testing.T) {
+	var orders orderRecorder
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if orders.record(t, r)%2 == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"status":"OK","data":{"id":1,"externalId":"ext-1"}}`))
+	})
+	var attempts []RequestAttempt
+	client.SetRetryPolicy(fastRetryPolicy(&attempts))
+
+	response, err := client.SubmitOrder(context.Background(), &PerpetualOrderModel{ID: "ext-1"})
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), response.Data.OrderID)
+	bodies := orders.recorded()
+	require.Len(t, bodies, 2)
+	assert.Equal(t, bodies[0], bodies[1], "The same body should be sent on retry")
+	assert.True(t, strings.Contains(bodies[0], `"ext-1"`))
+
+	// Without an external ID the POST is not idempotent
+	_, err = client.SubmitOrder(context.Background(), &PerpetualOrderModel{})
+	assert.ErrorIs(t, err, ErrServerError)
+	assert.Len(t, orders.recorded(), 3)
+}
+
+// duplicateOrderServer places the first submitted order but drops the connection before
+// responding, and rejects every resubmission as a duplicate
+func duplicateOrderServer(t *testing.T, orders *orderRecorder, lookups *atomic.Int32, placed bool) *APIClient {
+	return createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			lookups.Add(1)
+			assert.Equal(t, "/user/orders/external/ext-7", r.URL.Path)
+			if placed {
+				w.Write([]byte(`{"status":"OK","data":[` + testOrderJSON + `]}`))
+			} else {
+				w.Write([]byte(`{"status":"OK","data":[]}`))
+			}
+			return
+		}
+		if orders.record(t, r) > 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status":"ERROR","error":{"message":"Duplicate external id"}}`))
+			return
+		}
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if assert.NoError(t, err) {
+			conn.Close()
+		}
+	})
+}
+
+func TestSubmitOrder_ConfirmsOrderPlacedBeforeDisconnect(t *testing.T) {
+	var orders orderRecorder
+	var lookups atomic.Int32
+	client := duplicateOrderServer(t, &orders, &lookups, true)
+	var attempts []RequestAttempt
+	client.SetRetryPolicy(fastRetryPolicy(&attempts))
+
+	response, err := client.SubmitOrder(context.Background(), &PerpetualOrderModel{ID: "ext-7"})
+	require.NoError(t, err, "The duplicate rejection should be resolved by looking the order up")
+	assert.Equal(t, "OK", response.Status)
+	assert.Equal(t, uint(7), response.Data.OrderID)
+	assert.Equal(t, "ext-7", response.Data.ExternalID)
+	assert.Len(t, orders.recorded(), 2)
+	assert.Equal(t, int32(1), lookups.Load())
+}
+
+func TestSubmitOrder_KeepsRejectionWhenOrderWasNotPlaced(t *testing.T) {
+	var orders orderRecorder
+	var lookups atomic.Int32
+	client := duplicateOrderServer(t, &orders, &lookups, false)
+	var attempts []RequestAttempt
+	client.SetRetryPolicy(fastRetryPolicy(&attempts))
+
+	_, err := client.SubmitOrder(context.Background(), &PerpetualOrderModel{ID: "ext-7"})
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.HTTPStatus)
+	assert.Equal(t, int32(1), lookups.Load())
+
+	// The server now rejects the first attempt, which is final and needs no lookup
+	_, err = client.SubmitOrder(context.Background(), &PerpetualOrderModel{ID: "ext-7"})
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, int32(1), lookups.Load())
+}
+
+func TestDoRequest_ContextCancelStopsRetrying(t *testing.T) {
+	client := createMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	policy := DefaultRetryPolicy
+	policy.InitialBackoff = time.Hour
+	policy.MaxBackoff = time.Hour
+	client.SetRetryPolicy(policy)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetBalance(ctx)
+	assert.ErrorIs(t, err, ErrServerError)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
@@ -0,0 +1,60 @@
+//go:build cgo && !purego
+
+package sdk
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPureBackendMatchesFFI cross-checks the pure-Go backend against liborderffi on random inputs.
+func TestPureBackendMatchesFFI(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	randomFelt := func() *big.Int {
+		return new(big.Int).Rand(rng, feltUpperBound)
+	}
+	domain := createTestStarknetDomain()
+
+	for i := 0; i < 20; i++ {
+		args := []string{
+			fmt.Sprintf("%d", rng.Uint32()),
+			fmt.Sprintf("0x%x", randomFelt()),
+			fmt.Sprintf("%d", rng.Int63()-rng.Int63()),
+			fmt.Sprintf("0x%x", randomFelt()),
+			fmt.Sprintf("%d", rng.Int63()-rng.Int63()),
+			fmt.Sprintf("0x%x", randomFelt()),
+			fmt.Sprintf("%d", rng.Uint64()),
+			fmt.Sprintf("%d", rng.Uint32()),
+			fmt.Sprintf("%d", rng.Int63()),
+			fmt.Sprintf("0x%x", randomFelt()),
+		}
+
+		ffiHash, err := GetOrderHash(
+			args[0], args[1], args[2], args[3], args[4],
+			args[5], args[6], args[7], args[8], args[9],
+			domain.Name, domain.Version, domain.ChainID, domain.Revision,
+		)
+		require.NoError(t, err)
+
+		pureHash, err := getOrderHashPure(
+			args[0], args[1], args[2], args[3], args[4],
+			args[5], args[6], args[7], args[8], args[9],
+			domain,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, ffiHash, pureHash, "order hash mismatch for %v", args)
+
+		privateKeyHex := fmt.Sprintf("0x%x", new(big.Int).Rand(rng, starkOrder))
+		ffiSig, err := SignMessage(ffiHash, privateKeyHex)
+		require.NoError(t, err)
+
+		pureSig, err := signMessagePure(pureHash, privateKeyHex)
+		require.NoError(t, err)
+		assert.Equal(t, ffiSig, pureSig, "signature mismatch for %s", privateKeyHex)
+	}
+}
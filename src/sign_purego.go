@@ -0,0 +1,35 @@
+//go:build !cgo || purego
+
+package sdk
+
+// GetOrderHash computes the order hash using the provided parameters.
+func GetOrderHash(
+	positionID, baseAssetIDHex, baseAmount,
+	quoteAssetIDHex, quoteAmount,
+	feeAssetIDHex, feeAmount,
+	expiration, salt,
+	userPublicKeyHex,
+	domainName, domainVersion,
+	domainChainID, domainRevision string,
+) (string, error) {
+	return getOrderHashPure(
+		positionID, baseAssetIDHex, baseAmount,
+		quoteAssetIDHex, quoteAmount,
+		feeAssetIDHex, feeAmount,
+		expiration, salt,
+		userPublicKeyHex,
+		StarknetDomain{
+			Name:     domainName,
+			Version:  domainVersion,
+			ChainID:  domainChainID,
+			Revision: domainRevision,
+		},
+	)
+}
+
+// SignMessage signs a message using the provided private key.
+// It returns the signature as a hex string, where v, r and s are concatenated as left-padded 64-character hex strings.
+// The signature is in the format: {r}{s}{v}
+func SignMessage(messageHex, privateKeyHex string) (string, error) {
+	return signMessagePure(messageHex, privateKeyHex)
+}
@@ -0,0 +1,234 @@
+package sdk
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+// Stark curve parameters: y^2 = x^3 + alpha*x + beta over the field of order starkPrime.
+var (
+	starkPrime = hexToBig("0800000000000011000000000000000000000000000000000000000000000001")
+	starkAlpha = big.NewInt(1)
+	starkBeta  = hexToBig("06f21413efbe40de150e596d72f7a8c5609ad26c15c915c1f4cdfcb99cee9e89")
+	starkOrder = hexToBig("0800000000000010ffffffffffffffffb781126dcae7b2321e66a241adc64d2f")
+
+	starkGenerator = &ecPoint{
+		x: hexToBig("01ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca"),
+		y: hexToBig("005668060aa49730b7be4801df46ec62de53ecd11abe43a32873000c36e8dc1f"),
+	}
+
+	// feltUpperBound is 2^251, the exclusive upper bound for message hashes and signature components.
+	feltUpperBound = new(big.Int).Lsh(big.NewInt(1), 251)
+)
+
+var (
+	errInvalidMessageHash = errors.New("message hash must be less than 2^251")
+	errInvalidPrivateKey  = errors.New("private key must be in the range [1, curve order)")
+)
+
+func hexToBig(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex constant: " + s)
+	}
+	return v
+}
+
+// ecPoint is an affine point on the Stark curve. A nil *ecPoint is the point at infinity.
+type ecPoint struct {
+	x, y *big.Int
+}
+
+func (p *ecPoint) isOnCurve() bool {
+	if p == nil {
+		return true
+	}
+	lhs := new(big.Int).Mul(p.y, p.y)
+	lhs.Mod(lhs, starkPrime)
+
+	rhs := new(big.Int).Mul(p.x, p.x)
+	rhs.Mul(rhs, p.x)
+	rhs.Add(rhs, new(big.Int).Mul(starkAlpha, p.x))
+	rhs.Add(rhs, starkBeta)
+	rhs.Mod(rhs, starkPrime)
+
+	return lhs.Cmp(rhs) == 0
+}
+
+func ecAdd(p, q *ecPoint) *ecPoint {
+	if p == nil {
+		return q
+	}
+	if q == nil {
+		return p
+	}
+
+	if p.x.Cmp(q.x) == 0 {
+		sum := new(big.Int).Add(p.y, q.y)
+		if sum.Mod(sum, starkPrime).Sign() == 0 {
+			return nil
+		}
+		return ecDouble(p)
+	}
+
+	num := new(big.Int).Sub(q.y, p.y)
+	den := new(big.Int).Sub(q.x, p.x)
+	den.Mod(den, starkPrime)
+	slope := num.Mul(num, den.ModInverse(den, starkPrime))
+	slope.Mod(slope, starkPrime)
+
+	return ecFromSlope(p, q, slope)
+}
+
+func ecDouble(p *ecPoint) *ecPoint {
+	if p == nil || p.y.Sign() == 0 {
+		return nil
+	}
+
+	num := new(big.Int).Mul(p.x, p.x)
+	num.Mul(num, big.NewInt(3))
+	num.Add(num, starkAlpha)
+	den := new(big.Int).Lsh(p.y, 1)
+	den.Mod(den, starkPrime)
+	slope := num.Mul(num, den.ModInverse(den, starkPrime))
+	slope.Mod(slope, starkPrime)
+
+	return ecFromSlope(p, p, slope)
+}
+
+// ecFromSlope completes the chord-and-tangent addition of p and q given the slope of the line through them.
+func ecFromSlope(p, q *ecPoint, slope *big.Int) *ecPoint {
+	x := new(big.Int).Mul(slope, slope)
+	x.Sub(x, p.x)
+	x.Sub(x, q.x)
+	x.Mod(x, starkPrime)
+
+	y := new(big.Int).Sub(p.x, x)
+	y.Mul(y, slope)
+	y.Sub(y, p.y)
+	y.Mod(y, starkPrime)
+
+	return &ecPoint{x: x, y: y}
+}
+
+// ecMul computes k*p using double-and-add.
+func ecMul(p *ecPoint, k *big.Int) *ecPoint {
+	var result *ecPoint
+	addend := p
+	for i := 0; i < k.BitLen(); i++ {
+		if k.Bit(i) == 1 {
+			result = ecAdd(result, addend)
+		}
+		addend = ecDouble(addend)
+	}
+	return result
+}
+
+// starkPublicKey derives the public key (the x coordinate of privateKey*G) from a private key.
+func starkPublicKey(privateKey *big.Int) (*big.Int, error) {
+	if privateKey.Sign() <= 0 || privateKey.Cmp(starkOrder) >= 0 {
+		return nil, errInvalidPrivateKey
+	}
+	return ecMul(starkGenerator, privateKey).x, nil
+}
+
+// starkSign produces a deterministic ECDSA signature over the Stark curve, returning (r, s, v)
+// where v is the parity of the y coordinate of the nonce point.
+// The nonce is generated as in RFC 6979 with HMAC-SHA256, matching starknet-crypto.
+func starkSign(msgHash, privateKey *big.Int) (*big.Int, *big.Int, *big.Int, error) {
+	if msgHash.Sign() < 0 || msgHash.Cmp(feltUpperBound) >= 0 {
+		return nil, nil, nil, errInvalidMessageHash
+	}
+	if privateKey.Sign() <= 0 || privateKey.Cmp(starkOrder) >= 0 {
+		return nil, nil, nil, errInvalidPrivateKey
+	}
+
+	drbg := newRFC6979(privateKey, msgHash)
+	for {
+		k := drbg.nextK()
+
+		point := ecMul(starkGenerator, k)
+		r := point.x
+		if r.Sign() == 0 || r.Cmp(feltUpperBound) >= 0 {
+			continue
+		}
+
+		s := new(big.Int).Mul(r, privateKey)
+		s.Add(s, msgHash)
+		s.Mul(s, new(big.Int).ModInverse(k, starkOrder))
+		s.Mod(s, starkOrder)
+		if s.Sign() == 0 || s.Cmp(feltUpperBound) >= 0 {
+			continue
+		}
+
+		v := big.NewInt(int64(point.y.Bit(0)))
+		return r, s, v, nil
+	}
+}
+
+// starkVerify checks an (r, s) signature of msgHash against the full public key point.
+func starkVerify(msgHash, r, s *big.Int, publicKey *ecPoint) bool {
+	if r.Sign() <= 0 || r.Cmp(feltUpperBound) >= 0 || s.Sign() <= 0 || s.Cmp(starkOrder) >= 0 {
+		return false
+	}
+
+	w := new(big.Int).ModInverse(s, starkOrder)
+	u1 := new(big.Int).Mul(msgHash, w)
+	u1.Mod(u1, starkOrder)
+	u2 := new(big.Int).Mul(r, w)
+	u2.Mod(u2, starkOrder)
+
+	point := ecAdd(ecMul(starkGenerator, u1), ecMul(publicKey, u2))
+	return point != nil && point.x.Cmp(r) == 0
+}
+
+// rfc6979 is the HMAC-DRBG used to derive deterministic signing nonces.
+type rfc6979 struct {
+	k, v []byte
+}
+
+func newRFC6979(privateKey, msgHash *big.Int) *rfc6979 {
+	x := privateKey.FillBytes(make([]byte, 32))
+	h := msgHash.FillBytes(make([]byte, 32))
+
+	d := &rfc6979{
+		k: make([]byte, sha256.Size),
+		v: make([]byte, sha256.Size),
+	}
+	for i := range d.v {
+		d.v[i] = 0x01
+	}
+
+	d.k = d.mac(d.k, d.v, []byte{0x00}, x, h)
+	d.v = d.mac(d.k, d.v)
+	d.k = d.mac(d.k, d.v, []byte{0x01}, x, h)
+	d.v = d.mac(d.k, d.v)
+	return d
+}
+
+func (d *rfc6979) mac(key []byte, data ...[]byte) []byte {
+	m := hmac.New(sha256.New, key)
+	for _, b := range data {
+		m.Write(b)
+	}
+	return m.Sum(nil)
+}
+
+// nextK returns the next candidate nonce in [1, starkOrder).
+func (d *rfc6979) nextK() *big.Int {
+	for {
+		d.v = d.mac(d.k, d.v)
+		// The curve order is 252 bits, so the 256-bit output is shifted right by 4.
+		k := new(big.Int).SetBytes(d.v)
+		k.Rsh(k, 4)
+
+		d.k = d.mac(d.k, d.v, []byte{0x00})
+		d.v = d.mac(d.k, d.v)
+
+		if k.Sign() > 0 && k.Cmp(starkOrder) < 0 {
+			return k
+		}
+	}
+}
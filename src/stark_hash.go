@@ -0,0 +1,134 @@
+package sdk
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+)
+
+// Pedersen hash constant points. The first is the shift point, the remaining four are
+// the low/high generators for each of the two inputs.
+var pedersenPoints = [5]*ecPoint{
+	{
+		x: hexToBig("049ee3eba8c1600700ee1b87eb599f16716b0b1022947733551fde4050ca6804"),
+		y: hexToBig("03ca0cfe4b3bc6ddf346d49d06ea0ed34e621062c0e056c1d0405d266e10268a"),
+	},
+	{
+		x: hexToBig("0234287dcbaffe7f969c748655fca9e58fa8120b6d56eb0c1080d17957ebe47b"),
+		y: hexToBig("03b056f100f96fb21e889527d41f4e39940135dd7a6c94cc6ed0268ee89e5615"),
+	},
+	{
+		x: hexToBig("04fa56f376c83db33f9dab2656558f3399099ec1de5e3018b7a6932dba8aa378"),
+		y: hexToBig("03fa0984c931c9e38113e0c0e47e4401562761f92a7a23b45168f4e80ff5b54d"),
+	},
+	{
+		x: hexToBig("04ba4cc166be8dec764910f75b45f74b40c690c74709e90f3aa372f0bd2d6997"),
+		y: hexToBig("0040301cf5c1751f4b971e46c4ede85fcac5c59a5ce5ae7c48151f27b24b219c"),
+	},
+	{
+		x: hexToBig("054302dcb0e6cc1c6e44cca8f61a63bb2ca65048d53fb325d36ff12c49a58202"),
+		y: hexToBig("01b77b3e37d13504b348046268d8ae25ce98ad783c25561a879dcc77e99c2426"),
+	},
+}
+
+var pedersenLowMask = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 248), big.NewInt(1))
+
+// pedersenHash computes the Starknet Pedersen hash of two field elements.
+// Orders are hashed with Poseidon; it is kept to check the curve arithmetic against known vectors.
+func pedersenHash(a, b *big.Int) (*big.Int, error) {
+	result := pedersenPoints[0]
+	for i, x := range []*big.Int{a, b} {
+		if x.Sign() < 0 || x.Cmp(starkPrime) >= 0 {
+			return nil, fmt.Errorf("pedersen input %d is not a valid field element", i)
+		}
+		low := new(big.Int).And(x, pedersenLowMask)
+		high := new(big.Int).Rsh(x, 248)
+		result = ecAdd(result, ecMul(pedersenPoints[1+2*i], low))
+		result = ecAdd(result, ecMul(pedersenPoints[2+2*i], high))
+	}
+	return result.x, nil
+}
+
+// Poseidon (Hades) permutation parameters used by Starknet.
+const (
+	poseidonFullRounds    = 8
+	poseidonPartialRounds = 83
+	poseidonStateWidth    = 3
+)
+
+// poseidonRoundConstants are derived as sha256("Hades" + index) mod p, as in cairo-lang.
+var poseidonRoundConstants = func() [][poseidonStateWidth]*big.Int {
+	rounds := poseidonFullRounds + poseidonPartialRounds
+	constants := make([][poseidonStateWidth]*big.Int, rounds)
+	for i := range constants {
+		for j := 0; j < poseidonStateWidth; j++ {
+			digest := sha256.Sum256([]byte(fmt.Sprintf("Hades%d", poseidonStateWidth*i+j)))
+			c := new(big.Int).SetBytes(digest[:])
+			constants[i][j] = c.Mod(c, starkPrime)
+		}
+	}
+	return constants
+}()
+
+// poseidonPermute applies the Hades permutation to the state in place.
+func poseidonPermute(state *[poseidonStateWidth]*big.Int) {
+	half := poseidonFullRounds / 2
+	for round, constants := range poseidonRoundConstants {
+		full := round < half || round >= half+poseidonPartialRounds
+		for j := range state {
+			state[j].Add(state[j], constants[j])
+			if full || j == poseidonStateWidth-1 {
+				cube := new(big.Int).Mul(state[j], state[j])
+				state[j].Mul(cube, state[j])
+			}
+			state[j].Mod(state[j], starkPrime)
+		}
+		poseidonMix(state)
+	}
+}
+
+// poseidonMix multiplies the state by the MDS matrix [[3,1,1],[1,-1,1],[1,1,-2]].
+func poseidonMix(state *[poseidonStateWidth]*big.Int) {
+	a, b, c := state[0], state[1], state[2]
+	sum := new(big.Int).Add(a, b)
+	sum.Add(sum, c)
+
+	s0 := new(big.Int).Add(sum, new(big.Int).Lsh(a, 1))
+	s1 := new(big.Int).Sub(sum, new(big.Int).Lsh(b, 1))
+	s2 := new(big.Int).Sub(sum, new(big.Int).Mul(c, big.NewInt(3)))
+
+	state[0] = s0.Mod(s0, starkPrime)
+	state[1] = s1.Mod(s1, starkPrime)
+	state[2] = s2.Mod(s2, starkPrime)
+}
+
+// poseidonHash computes the Starknet Poseidon hash of two field elements.
+func poseidonHash(a, b *big.Int) *big.Int {
+	state := [poseidonStateWidth]*big.Int{
+		new(big.Int).Mod(a, starkPrime),
+		new(big.Int).Mod(b, starkPrime),
+		big.NewInt(2),
+	}
+	poseidonPermute(&state)
+	return state[0]
+}
+
+// poseidonHashMany computes the Starknet Poseidon sponge hash of a sequence of field elements.
+func poseidonHashMany(values ...*big.Int) *big.Int {
+	padded := make([]*big.Int, 0, len(values)+2)
+	padded = append(padded, values...)
+	padded = append(padded, big.NewInt(1))
+	if len(padded)%2 != 0 {
+		padded = append(padded, big.NewInt(0))
+	}
+
+	state := [poseidonStateWidth]*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(0)}
+	for i := 0; i < len(padded); i += 2 {
+		state[0].Add(state[0], padded[i])
+		state[0].Mod(state[0], starkPrime)
+		state[1].Add(state[1], padded[i+1])
+		state[1].Mod(state[1], starkPrime)
+		poseidonPermute(&state)
+	}
+	return state[0]
+}
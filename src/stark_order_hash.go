@@ -0,0 +1,180 @@
+package sdk
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// SNIP-12 (revision 1) type hashes, i.e. starknet_keccak of the encoded type definitions.
+var (
+	// "StarknetDomain"("name":"shortstring","version":"shortstring","chainId":"shortstring","revision":"shortstring")
+	starknetDomainTypeHash = hexToBig("1ff2f602e42168014d405a94f75e8a93d640751d71d16311266e140d8b0a210")
+
+	// "Order"("position_id":"felt","base_asset_id":"AssetId","base_amount":"i64","quote_asset_id":"AssetId",
+	// "quote_amount":"i64","fee_asset_id":"AssetId","fee_amount":"u64","expiration":"Timestamp","salt":"felt")
+	// "PositionId"("value":"u32")"AssetId"("value":"felt")"Timestamp"("seconds":"u64")
+	orderTypeHash = hexToBig("36da8d51815527cabfaa9c982f564c80fa7429616739306036f1f9b608dd112")
+
+	starknetMessagePrefix = shortStringToFelt("StarkNet Message")
+)
+
+// shortStringToFelt encodes an ASCII string of at most 31 characters as a big-endian field element.
+func shortStringToFelt(s string) *big.Int {
+	return new(big.Int).SetBytes([]byte(s))
+}
+
+// starkOrderMessage mirrors the Order struct of the Starknet perpetuals contract.
+type starkOrderMessage struct {
+	positionID    uint32
+	baseAssetID   *big.Int
+	baseAmount    int64
+	quoteAssetID  *big.Int
+	quoteAmount   int64
+	feeAssetID    *big.Int
+	feeAmount     uint64
+	expiration    uint64
+	salt          *big.Int
+	userPublicKey *big.Int
+}
+
+func signedToFelt(v int64) *big.Int {
+	f := big.NewInt(v)
+	return f.Mod(f, starkPrime)
+}
+
+func starknetDomainHash(domain StarknetDomain) (*big.Int, error) {
+	for _, s := range []string{domain.Name, domain.Version, domain.ChainID} {
+		if len(s) > 31 {
+			return nil, fmt.Errorf("domain field %q exceeds 31 characters", s)
+		}
+	}
+	revision, err := strconv.ParseUint(domain.Revision, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain revision %q: %w", domain.Revision, err)
+	}
+
+	return poseidonHashMany(
+		starknetDomainTypeHash,
+		shortStringToFelt(domain.Name),
+		shortStringToFelt(domain.Version),
+		shortStringToFelt(domain.ChainID),
+		new(big.Int).SetUint64(revision),
+	), nil
+}
+
+// messageHash computes the SNIP-12 message hash of the order for the given domain.
+func (o starkOrderMessage) messageHash(domain StarknetDomain) (*big.Int, error) {
+	domainHash, err := starknetDomainHash(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	structHash := poseidonHashMany(
+		orderTypeHash,
+		new(big.Int).SetUint64(uint64(o.positionID)),
+		o.baseAssetID,
+		signedToFelt(o.baseAmount),
+		o.quoteAssetID,
+		signedToFelt(o.quoteAmount),
+		o.feeAssetID,
+		new(big.Int).SetUint64(o.feeAmount),
+		new(big.Int).SetUint64(o.expiration),
+		o.salt,
+	)
+
+	return poseidonHashMany(starknetMessagePrefix, domainHash, o.userPublicKey, structHash), nil
+}
+
+// parseFeltHex parses a 0x-prefixed (or bare) hex string into a field element.
+func parseFeltHex(name, s string) (*big.Int, error) {
+	if err := isHexString(s); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	v, ok := new(big.Int).SetString(s, 16)
+	if !ok || v.Cmp(starkPrime) >= 0 {
+		return nil, fmt.Errorf("invalid %s: not a field element", name)
+	}
+	return v, nil
+}
+
+// parseFeltDec parses a non-negative decimal string into a field element.
+func parseFeltDec(name, s string) (*big.Int, error) {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok || v.Sign() < 0 || v.Cmp(starkPrime) >= 0 {
+		return nil, fmt.Errorf("invalid %s: %q is not a field element", name, s)
+	}
+	return v, nil
+}
+
+// getOrderHashPure is the pure-Go counterpart of the FFI get_order_hash and accepts the same string inputs.
+func getOrderHashPure(
+	positionID, baseAssetIDHex, baseAmount,
+	quoteAssetIDHex, quoteAmount,
+	feeAssetIDHex, feeAmount,
+	expiration, salt,
+	userPublicKeyHex string,
+	domain StarknetDomain,
+) (string, error) {
+	var order starkOrderMessage
+
+	position, err := strconv.ParseUint(positionID, 10, 32)
+	if err != nil {
+		return "", fmt.Errorf("invalid position id: %w", err)
+	}
+	order.positionID = uint32(position)
+
+	if order.baseAssetID, err = parseFeltHex("base asset id", baseAssetIDHex); err != nil {
+		return "", err
+	}
+	if order.baseAmount, err = strconv.ParseInt(baseAmount, 10, 64); err != nil {
+		return "", fmt.Errorf("invalid base amount: %w", err)
+	}
+	if order.quoteAssetID, err = parseFeltHex("quote asset id", quoteAssetIDHex); err != nil {
+		return "", err
+	}
+	if order.quoteAmount, err = strconv.ParseInt(quoteAmount, 10, 64); err != nil {
+		return "", fmt.Errorf("invalid quote amount: %w", err)
+	}
+	if order.feeAssetID, err = parseFeltHex("fee asset id", feeAssetIDHex); err != nil {
+		return "", err
+	}
+	if order.feeAmount, err = strconv.ParseUint(feeAmount, 10, 64); err != nil {
+		return "", fmt.Errorf("invalid fee amount: %w", err)
+	}
+	if order.expiration, err = strconv.ParseUint(expiration, 10, 64); err != nil {
+		return "", fmt.Errorf("invalid expiration: %w", err)
+	}
+	if order.salt, err = parseFeltDec("salt", salt); err != nil {
+		return "", err
+	}
+	if order.userPublicKey, err = parseFeltHex("user public key", userPublicKeyHex); err != nil {
+		return "", err
+	}
+
+	hash, err := order.messageHash(domain)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("0x%x", hash), nil
+}
+
+// signMessagePure is the pure-Go counterpart of the FFI sign_message and returns the same {r}{s}{v} format.
+func signMessagePure(messageHex, privateKeyHex string) (string, error) {
+	msgHash, err := parseFeltHex("message hash", messageHex)
+	if err != nil {
+		return "", err
+	}
+	privateKey, err := parseFeltHex("private key", privateKeyHex)
+	if err != nil {
+		return "", err
+	}
+
+	r, s, v, err := starkSign(msgHash, privateKey)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%064x%064x%064x", r, s, v), nil
+}
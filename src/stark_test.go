@@ -0,0 +1,88 @@
+package sdk
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStarkCurveConstants(t *testing.T) {
+	assert.True(t, starkGenerator.isOnCurve(), "generator should be on the curve")
+	assert.Nil(t, ecMul(starkGenerator, starkOrder), "order * G should be the point at infinity")
+
+	for i, p := range pedersenPoints {
+		assert.True(t, p.isOnCurve(), "pedersen point %d should be on the curve", i)
+	}
+}
+
+func TestPedersenHash(t *testing.T) {
+	hash, err := pedersenHash(
+		hexToBig("03d937c035c878245caf64531a5756109c53068da139362728feb561405371cb"),
+		hexToBig("0208a0a10250e382e1e4bbe2880906c2791bf6275695e02fbbc6aeff9cd8b31a"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "0x30e480bed5fe53fa909cc0f8c4d99b8f9f2c016be4c41e13a4848797979c662", "0x"+hash.Text(16))
+
+	_, err = pedersenHash(starkPrime, big.NewInt(0))
+	assert.Error(t, err, "inputs outside the field should be rejected")
+}
+
+func TestPoseidonHash(t *testing.T) {
+	assert.Equal(t, "0x6861759ea556a2339dd92f9562a30b9e58e2ad98109ae4780b7fd8eac77fe6f",
+		"0x"+poseidonRoundConstants[0][0].Text(16))
+	assert.Equal(t, "0x5d44a3decb2b2e0cc71071f7b802f45dd792d064f0fc7316c46514f70f9891a",
+		"0x"+poseidonHash(big.NewInt(1), big.NewInt(2)).Text(16))
+}
+
+func TestGetOrderHashPure(t *testing.T) {
+	hash, err := getOrderHashPure(
+		"100", "0x2", "100",
+		"0x1", "-156",
+		"0x1", "74",
+		"100", "123",
+		"0x5d05989e9302dcebc74e241001e3e3ac3f4402ccf2f8e6f74b034b07ad6a904",
+		StarknetDomain{Name: "Perpetuals", Version: "v0", ChainID: "SN_SEPOLIA", Revision: "1"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "0x4de4c009e0d0c5a70a7da0e2039fb2b99f376d53496f89d9f437e736add6b48", hash)
+
+	_, err = getOrderHashPure(
+		"100", "0xzz", "100",
+		"0x1", "-156",
+		"0x1", "74",
+		"100", "123",
+		"0x5d05989e9302dcebc74e241001e3e3ac3f4402ccf2f8e6f74b034b07ad6a904",
+		StarknetDomain{Name: "Perpetuals", Version: "v0", ChainID: "SN_SEPOLIA", Revision: "1"},
+	)
+	assert.Error(t, err, "invalid asset id should be rejected")
+}
+
+func TestSignMessagePure(t *testing.T) {
+	privateKeyHex := "0x1234def56789012345678901234567890123456789012345678901234567890"
+	msgHash := "0x4de4c009e0d0c5a70a7da0e2039fb2b99f376d53496f89d9f437e736add6b48"
+
+	sig, err := signMessagePure(msgHash, privateKeyHex)
+	require.NoError(t, err)
+	require.Len(t, sig, 192)
+
+	r, _ := new(big.Int).SetString(sig[:64], 16)
+	s, _ := new(big.Int).SetString(sig[64:128], 16)
+	assert.Equal(t, "2744225103614379349530169149569415648483556705538760809691766060588698917266", r.String())
+	assert.Equal(t, "575134845329043509424821214199431073576156064822439379079045654927136672163", s.String())
+
+	privateKey := hexToBig(privateKeyHex[2:])
+	publicKey := ecMul(starkGenerator, privateKey)
+	assert.True(t, starkVerify(hexToBig(msgHash[2:]), r, s, publicKey), "signature should verify")
+
+}
+
+func TestStarkPublicKey(t *testing.T) {
+	publicKey, err := starkPublicKey(hexToBig(TestPrivateKeyHex[2:]))
+	require.NoError(t, err)
+	assert.Equal(t, TestPublicKeyHex, "0x"+publicKey.Text(16))
+
+	_, err = starkPublicKey(big.NewInt(0))
+	assert.ErrorIs(t, err, errInvalidPrivateKey)
+}
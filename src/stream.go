@@ -0,0 +1,625 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrStreamURLNotSet   = errors.New("stream url is not set")
+	ErrStreamSequenceGap = errors.New("stream sequence gap")
+)
+
+const (
+	// streamBufferSize is the number of decoded messages buffered per subscription
+	streamBufferSize = 256
+	// streamEventBufferSize is the number of connection events buffered per subscription
+	streamEventBufferSize = 32
+
+	// DefaultPingInterval is how often streams ping the exchange to detect dead connections
+	DefaultPingInterval = 15 * time.Second
+	// DefaultPongTimeout is how long streams wait past a ping interval for any traffic before reconnecting
+	DefaultPongTimeout = 10 * time.Second
+
+	streamWriteTimeout = 5 * time.Second
+)
+
+// ReconnectPolicy controls how streams recover from dropped connections. The delay before
+// attempt n is InitialBackoff * Multiplier^(n-1), capped at MaxBackoff, with up to half of it
+// replaced by random jitter.
+type ReconnectPolicy struct {
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// MaxAttempts is the number of consecutive failed reconnects after which the subscription
+	// ends with an error. Zero retries forever.
+	MaxAttempts int
+}
+
+// DefaultReconnectPolicy retries forever, backing off from 500ms up to 30s
+var DefaultReconnectPolicy = ReconnectPolicy{
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     2,
+}
+
+// backoff returns the jittered delay before the given reconnect attempt, starting at 1
+func (p ReconnectPolicy) backoff(attempt int) time.Duration {
+	return jitteredBackoff(p.InitialBackoff, p.MaxBackoff, p.Multiplier, attempt)
+}
+
+// StreamEventType identifies a change in the state of a stream connection
+type StreamEventType string
+
+const (
+	StreamEventDisconnected StreamEventType = "DISCONNECTED"
+	StreamEventReconnected  StreamEventType = "RECONNECTED"
+)
+
+// StreamEvent reports that a connection of a subscription dropped or was restored.
+// After a Reconnected event, state built from earlier messages of that path should be resynced.
+type StreamEvent struct {
+	Type StreamEventType
+	Path string
+	// Err is the reason for a disconnect
+	Err error
+	// Attempts is the number of attempts a reconnect took
+	Attempts int
+}
+
+// StreamClient delivers real-time exchange data over websockets and manages the connections of
+// all its subscriptions. The exchange serves every channel on its own path, so each subscribed
+// market uses a dedicated connection. Dropped connections are reopened according to the reconnect policy.
+type StreamClient struct {
+	endpointConfig EndpointConfig
+	apiKey         string
+	dialer         *websocket.Dialer
+
+	mu            sync.Mutex
+	reconnect     ReconnectPolicy
+	pingInterval  time.Duration
+	pongTimeout   time.Duration
+	subscriptions map[chan struct{}]context.CancelFunc
+}
+
+// NewStreamClient creates a new stream client instance
+func NewStreamClient(cfg EndpointConfig, apiKey string) *StreamClient {
+	return &StreamClient{
+		endpointConfig: cfg,
+		apiKey:         apiKey,
+		dialer:         websocket.DefaultDialer,
+		reconnect:      DefaultReconnectPolicy,
+		pingInterval:   DefaultPingInterval,
+		pongTimeout:    DefaultPongTimeout,
+		subscriptions:  map[chan struct{}]context.CancelFunc{},
+	}
+}
+
+// Close ends every active subscription of the client and waits for their connections to shut down
+func (c *StreamClient) Close() {
+	c.mu.Lock()
+	active := make(map[chan struct{}]context.CancelFunc, len(c.subscriptions))
+	for done, cancel := range c.subscriptions {
+		active[done] = cancel
+	}
+	c.mu.Unlock()
+
+	for done, cancel := range active {
+		cancel()
+		<-done
+	}
+}
+
+// SetReconnectPolicy changes the reconnect policy of subscriptions created afterwards
+func (c *StreamClient) SetReconnectPolicy(policy ReconnectPolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reconnect = policy
+}
+
+// SetHeartbeat changes how often subscriptions created afterwards ping the exchange, and how
+// long past a ping interval they wait for any traffic before treating the connection as dead.
+// A non-positive interval disables pings and dead connection detection.
+func (c *StreamClient) SetHeartbeat(pingInterval, pongTimeout time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pingInterval = pingInterval
+	c.pongTimeout = pongTimeout
+}
+
+// streamMessage is the envelope shared by all stream messages
+type streamMessage struct {
+	Type      string          `json:"type"`
+	Data      json.RawMessage `json:"data"`
+	Error     string          `json:"error"`
+	Timestamp int64           `json:"ts"`
+	Seq       int64           `json:"seq"`
+}
+
+// Subscription delivers the decoded messages of a stream on C and connection state changes
+// on Events. C is closed once the subscription ends, after which Err reports why.
+// Events are dropped rather than stalling the stream when nobody reads them.
+type Subscription[T any] struct {
+	C      <-chan T
+	Events <-chan StreamEvent
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// Close ends the subscription and waits for its connections to shut down
+func (s *Subscription[T]) Close() {
+	s.cancel()
+	<-s.done
+}
+
+// Done is closed when the subscription ends
+func (s *Subscription[T]) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns the error that ended the subscription. It is nil while the subscription
+// is running and when it was ended by Close or its context.
+func (s *Subscription[T]) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// subscribe connects to every path and merges the decoded messages into one subscription.
+// Private streams are authenticated with the client's API key. The initial connections must
+// succeed; afterwards dropped connections are reopened, and the subscription only ends when
+// the exchange reports an error, a message cannot be decoded or reconnecting gives up.
+func subscribe[T any](ctx context.Context, c *StreamClient, paths []string, private bool, decode func(streamMessage) (T, error)) (*Subscription[T], error) {
+	if c.endpointConfig.StreamURL == "" {
+		return nil, ErrStreamURLNotSet
+	}
+	if private && c.apiKey == "" {
+		return nil, ErrAPIKeyNotSet
+	}
+
+	c.mu.Lock()
+	policy, pingInterval, pongTimeout := c.reconnect, c.pingInterval, c.pongTimeout
+	c.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	conns := make([]*streamConn, 0, len(paths))
+	for _, path := range paths {
+		conn := &streamConn{
+			client:       c,
+			path:         path,
+			private:      private,
+			policy:       policy,
+			pingInterval: pingInterval,
+			pongTimeout:  pongTimeout,
+		}
+		if err := conn.connect(ctx); err != nil {
+			cancel()
+			for _, open := range conns {
+				open.close()
+			}
+			return nil, err
+		}
+		conns = append(conns, conn)
+	}
+
+	out := make(chan T, streamBufferSize)
+	events := make(chan StreamEvent, streamEventBufferSize)
+	sub := &Subscription[T]{C: out, Events: events, cancel: cancel, done: make(chan struct{})}
+
+	notify := func(event StreamEvent) {
+		select {
+		case events <- event:
+		default:
+		}
+	}
+
+	c.mu.Lock()
+	c.subscriptions[sub.done] = cancel
+	c.mu.Unlock()
+
+	var wg sync.WaitGroup
+	var failOnce sync.Once
+	for _, conn := range conns {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := conn.run(ctx, func(msg streamMessage) error {
+				value, err := decode(msg)
+				if err != nil {
+					return fmt.Errorf("failed to decode %s message: %w", conn.path, err)
+				}
+				select {
+				case out <- value:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}, notify)
+			if err != nil && ctx.Err() == nil {
+				failOnce.Do(func() { sub.err = err })
+				cancel()
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		cancel()
+		c.mu.Lock()
+		delete(c.subscriptions, sub.done)
+		c.mu.Unlock()
+		close(sub.done)
+		close(out)
+		close(events)
+	}()
+
+	return sub, nil
+}
+
+// streamConn is a websocket connection serving a single stream path
+type streamConn struct {
+	client       *StreamClient
+	path         string
+	private      bool
+	policy       ReconnectPolicy
+	pingInterval time.Duration
+	pongTimeout  time.Duration
+	lastSeq      int64
+
+	mu   sync.Mutex
+	conn *websocket.Conn
+}
+
+// streamFatalError wraps errors that must end the subscription instead of triggering a reconnect
+type streamFatalError struct {
+	err error
+}
+
+func (e *streamFatalError) Error() string { return e.err.Error() }
+func (e *streamFatalError) Unwrap() error { return e.err }
+
+func (s *streamConn) connect(ctx context.Context) error {
+	header := http.Header{}
+	if s.private {
+		header.Set("X-API-Key", s.client.apiKey)
+	}
+
+	conn, resp, err := s.client.dialer.DialContext(ctx, s.client.endpointConfig.StreamURL+s.path, header)
+	if err != nil {
+		if resp != nil {
+			return fmt.Errorf("failed to connect to stream %s (status %d): %w", s.path, resp.StatusCode, err)
+		}
+		return fmt.Errorf("failed to connect to stream %s: %w", s.path, err)
+	}
+
+	if s.pingInterval > 0 {
+		conn.SetReadDeadline(time.Now().Add(s.pingInterval + s.pongTimeout))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(s.pingInterval + s.pongTimeout))
+		})
+		conn.SetPingHandler(func(data string) error {
+			conn.SetReadDeadline(time.Now().Add(s.pingInterval + s.pongTimeout))
+			err := conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(streamWriteTimeout))
+			if errors.Is(err, websocket.ErrCloseSent) {
+				return nil
+			}
+			return err
+		})
+	}
+
+	s.mu.Lock()
+	s.conn = conn
+	s.lastSeq = 0
+	s.mu.Unlock()
+
+	// The context may have ended while dialing, after run's close hook fired
+	if ctx.Err() != nil {
+		s.close()
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (s *streamConn) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+func (s *streamConn) current() *websocket.Conn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn
+}
+
+// run reads messages until the context ends or a fatal error occurs. Read failures, missed
+// heartbeats and sequence gaps drop the connection and reopen it, so the exchange starts
+// the stream over, e.g. with a fresh order book snapshot.
+func (s *streamConn) run(ctx context.Context, handle func(streamMessage) error, notify func(StreamEvent)) error {
+	stop := context.AfterFunc(ctx, s.close)
+	defer stop()
+	defer s.close()
+
+	for {
+		err := s.read(ctx, handle)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var fatal *streamFatalError
+		if errors.As(err, &fatal) {
+			return fatal.err
+		}
+
+		s.close()
+		notify(StreamEvent{Type: StreamEventDisconnected, Path: s.path, Err: err})
+
+		attempts, err := s.reconnect(ctx)
+		if err != nil {
+			return err
+		}
+		notify(StreamEvent{Type: StreamEventReconnected, Path: s.path, Attempts: attempts})
+	}
+}
+
+// read consumes messages from the current connection until it fails, pinging the exchange meanwhile
+func (s *streamConn) read(ctx context.Context, handle func(streamMessage) error) error {
+	conn := s.current()
+	if conn == nil {
+		return ctx.Err()
+	}
+
+	if s.pingInterval > 0 {
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			ticker := time.NewTicker(s.pingInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteTimeout)); err != nil {
+						conn.Close()
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	for {
+		var msg streamMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return fmt.Errorf("stream %s: %w", s.path, err)
+		}
+		if s.pingInterval > 0 {
+			conn.SetReadDeadline(time.Now().Add(s.pingInterval + s.pongTimeout))
+		}
+
+		if msg.Error != "" {
+			return &streamFatalError{fmt.Errorf("stream %s returned error: %s", s.path, msg.Error)}
+		}
+		if s.lastSeq != 0 && msg.Seq != s.lastSeq+1 {
+			return fmt.Errorf("stream %s: %w: expected %d, got %d", s.path, ErrStreamSequenceGap, s.lastSeq+1, msg.Seq)
+		}
+		s.lastSeq = msg.Seq
+
+		if err := handle(msg); err != nil {
+			return &streamFatalError{err}
+		}
+	}
+}
+
+// reconnect reopens the connection with backoff, returning the number of attempts it took
+func (s *streamConn) reconnect(ctx context.Context) (int, error) {
+	for attempt := 1; ; attempt++ {
+		timer := time.NewTimer(s.policy.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, ctx.Err()
+		case <-timer.C:
+		}
+
+		err := s.connect(ctx)
+		if err == nil {
+			return attempt, nil
+		}
+		if ctx.Err() != nil {
+			return attempt, ctx.Err()
+		}
+		if s.policy.MaxAttempts > 0 && attempt >= s.policy.MaxAttempts {
+			return attempt, fmt.Errorf("giving up after %d reconnect attempts: %w", attempt, err)
+		}
+	}
+}
+
+// marketPaths returns the stream path of each market, or the all-markets path when none are given
+func marketPaths(prefix string, markets []string) []string {
+	if len(markets) == 0 {
+		return []string{prefix}
+	}
+	paths := make([]string, len(markets))
+	for i, market := range markets {
+		paths[i] = prefix + "/" + url.PathEscape(market)
+	}
+	return paths
+}
+
+// ===== Order Book Streams =====
+
+// streamOrderbookLevel is the compact level encoding used by the order book stream
+type streamOrderbookLevel struct {
+	Price decimal.Decimal `json:"p"`
+	Qty   decimal.Decimal `json:"q"`
+}
+
+type streamOrderbookData struct {
+	Market string                 `json:"m"`
+	Bid    []streamOrderbookLevel `json:"b"`
+	Ask    []streamOrderbookLevel `json:"a"`
+}
+
+func toOrderbookLevels(levels []streamOrderbookLevel) []OrderbookLevel {
+	result := make([]OrderbookLevel, len(levels))
+	for i, l := range levels {
+		result[i] = OrderbookLevel{Price: l.Price, Qty: l.Qty}
+	}
+	return result
+}
+
+func decodeOrderbookUpdate(msg streamMessage) (OrderbookUpdate, error) {
+	var data streamOrderbookData
+	if err := json.Unmarshal(msg.Data, &data); err != nil {
+		return OrderbookUpdate{}, err
+	}
+	return OrderbookUpdate{
+		Type:      OrderbookUpdateType(msg.Type),
+		Market:    data.Market,
+		Bid:       toOrderbookLevels(data.Bid),
+		Ask:       toOrderbookLevels(data.Ask),
+		Seq:       msg.Seq,
+		Timestamp: msg.Timestamp,
+	}, nil
+}
+
+// SubscribeOrderbooks streams order book snapshots and deltas for the given markets, or for
+// all markets when none are given. Every connection starts with a snapshot, and a sequence
+// gap triggers a reconnect that is again followed by a fresh snapshot.
+func (c *StreamClient) SubscribeOrderbooks(ctx context.Context, markets ...string) (*Subscription[OrderbookUpdate], error) {
+	return subscribe(ctx, c, marketPaths("/orderbooks", markets), false, decodeOrderbookUpdate)
+}
+
+// ===== Account Streams =====
+
+type streamAccountData struct {
+	Orders    []OrderModel    `json:"orders"`
+	Trades    []TradeModel    `json:"trades"`
+	Positions []PositionModel `json:"positions"`
+	Balance   *BalanceModel   `json:"balance"`
+}
+
+func decodeAccountUpdate(msg streamMessage) (AccountUpdate, error) {
+	var data streamAccountData
+	if len(msg.Data) > 0 {
+		if err := json.Unmarshal(msg.Data, &data); err != nil {
+			return AccountUpdate{}, err
+		}
+	}
+	return AccountUpdate{
+		Type:      AccountUpdateType(msg.Type),
+		Orders:    data.Orders,
+		Trades:    data.Trades,
+		Positions: data.Positions,
+		Balance:   data.Balance,
+		Seq:       msg.Seq,
+		Timestamp: msg.Timestamp,
+	}, nil
+}
+
+// SubscribeAccount streams the order, trade, position and balance updates of the account
+// the client's API key belongs to
+func (c *StreamClient) SubscribeAccount(ctx context.Context) (*Subscription[AccountUpdate], error) {
+	return subscribe(ctx, c, []string{"/account"}, true, decodeAccountUpdate)
+}
+
+// ===== Market Data Streams =====
+
+func decodePublicTradesUpdate(msg streamMessage) (PublicTradesUpdate, error) {
+	var trades []PublicTradeModel
+	if err := json.Unmarshal(msg.Data, &trades); err != nil {
+		return PublicTradesUpdate{}, err
+	}
+	return PublicTradesUpdate{Trades: trades, Seq: msg.Seq, Timestamp: msg.Timestamp}, nil
+}
+
+// SubscribePublicTrades streams the trades of the given markets, or of all markets when none are given
+func (c *StreamClient) SubscribePublicTrades(ctx context.Context, markets ...string) (*Subscription[PublicTradesUpdate], error) {
+	return subscribe(ctx, c, marketPaths("/publicTrades", markets), false, decodePublicTradesUpdate)
+}
+
+// SubscribeCandles streams the candles of a market as they form
+func (c *StreamClient) SubscribeCandles(ctx context.Context, market string, candleType CandleType, interval CandleInterval) (*Subscription[CandleUpdate], error) {
+	if candleType == "" {
+		candleType = CandleTypeTrades
+	}
+	if interval == "" {
+		return nil, fmt.Errorf("candle interval must be provided")
+	}
+
+	path := "/candles/" + url.PathEscape(market) + "/" + string(candleType) + "?" + url.Values{"interval": {string(interval)}}.Encode()
+	return subscribe(ctx, c, []string{path}, false, func(msg streamMessage) (CandleUpdate, error) {
+		var candles []CandleModel
+		if err := json.Unmarshal(msg.Data, &candles); err != nil {
+			return CandleUpdate{}, err
+		}
+		return CandleUpdate{
+			Market:    market,
+			Type:      candleType,
+			Interval:  interval,
+			Candles:   candles,
+			Seq:       msg.Seq,
+			Timestamp: msg.Timestamp,
+		}, nil
+	})
+}
+
+type streamPriceData struct {
+	Market    string          `json:"m"`
+	Price     decimal.Decimal `json:"p"`
+	Timestamp int64           `json:"ts"`
+}
+
+func decodePriceUpdate(msg streamMessage) (PriceUpdate, error) {
+	var data streamPriceData
+	if err := json.Unmarshal(msg.Data, &data); err != nil {
+		return PriceUpdate{}, err
+	}
+	return PriceUpdate{Market: data.Market, Price: data.Price, Timestamp: data.Timestamp, Seq: msg.Seq}, nil
+}
+
+// SubscribeMarkPrices streams the mark prices of the given markets, or of all markets when none are given
+func (c *StreamClient) SubscribeMarkPrices(ctx context.Context, markets ...string) (*Subscription[PriceUpdate], error) {
+	return subscribe(ctx, c, marketPaths("/prices/mark", markets), false, decodePriceUpdate)
+}
+
+// SubscribeIndexPrices streams the index prices of the given markets, or of all markets when none are given
+func (c *StreamClient) SubscribeIndexPrices(ctx context.Context, markets ...string) (*Subscription[PriceUpdate], error) {
+	return subscribe(ctx, c, marketPaths("/prices/index", markets), false, decodePriceUpdate)
+}
+
+func decodeFundingRateUpdate(msg streamMessage) (FundingRateUpdate, error) {
+	var data FundingRateModel
+	if err := json.Unmarshal(msg.Data, &data); err != nil {
+		return FundingRateUpdate{}, err
+	}
+	return FundingRateUpdate{
+		Market:      data.Market,
+		FundingRate: data.FundingRate,
+		FundingTime: data.Timestamp,
+		Seq:         msg.Seq,
+	}, nil
+}
+
+// SubscribeFundingRates streams the funding rate changes of the given markets, or of all markets when none are given
+func (c *StreamClient) SubscribeFundingRates(ctx context.Context, markets ...string) (*Subscription[FundingRateUpdate], error) {
+	return subscribe(ctx, c, marketPaths("/funding", markets), false, decodeFundingRateUpdate)
+}
@@ -0,0 +1,412 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testStreamServer is a local websocket server that hands every accepted connection to serve
+// together with the number of connections previously accepted on the same path.
+type testStreamServer struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	connections map[string]int
+}
+
+func newTestStreamServer(t *testing.T, serve func(r *http.Request, conn *websocket.Conn, attempt int)) *testStreamServer {
+	t.Helper()
+	s := &testStreamServer{connections: map[string]int{}}
+	upgrader := websocket.Upgrader{}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		s.mu.Lock()
+		attempt := s.connections[r.URL.Path]
+		s.connections[r.URL.Path]++
+		s.mu.Unlock()
+
+		serve(r, conn, attempt)
+		// Keep the connection open until the client goes away
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testStreamServer) client() *StreamClient {
+	client := NewStreamClient(EndpointConfig{StreamURL: "ws" + strings.TrimPrefix(s.URL, "http")}, TestAPIKey)
+	client.SetReconnectPolicy(ReconnectPolicy{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond, Multiplier: 2})
+	return client
+}
+
+func (s *testStreamServer) connectionCount(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connections[path]
+}
+
+func orderbookMessage(msgType string, seq int64, market, bid, ask string) string {
+	return fmt.Sprintf(`{"type":%q,"data":{"m":%q,"b":[%s],"a":[%s]},"ts":1704067200000,"seq":%d}`, msgType, market, bid, ask, seq)
+}
+
+func receive[T any](t *testing.T, sub *Subscription[T]) T {
+	t.Helper()
+	select {
+	case v, ok := <-sub.C:
+		require.True(t, ok, "Subscription ended unexpectedly: %v", sub.Err())
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for a stream message")
+	}
+	var zero T
+	return zero
+}
+
+func TestStreamClient_SubscribeOrderbooks(t *testing.T) {
+	server := newTestStreamServer(t, func(r *http.Request, conn *websocket.Conn, attempt int) {
+		market := strings.TrimPrefix(r.URL.Path, "/orderbooks/")
+		conn.WriteMessage(websocket.TextMessage, []byte(orderbookMessage("SNAPSHOT", 1, market, `{"p":"42999","q":"0.5"}`, `{"p":"43001","q":"0.3"}`)))
+		conn.WriteMessage(websocket.TextMessage, []byte(orderbookMessage("DELTA", 2, market, `{"p":"42999","q":"-0.2"}`, "")))
+	})
+
+	sub, err := server.client().SubscribeOrderbooks(context.Background(), "BTC-USD", "ETH-USD")
+	require.NoError(t, err)
+	defer sub.Close()
+
+	updates := map[string][]OrderbookUpdate{}
+	for range 4 {
+		update := receive(t, sub)
+		updates[update.Market] = append(updates[update.Market], update)
+	}
+
+	for _, market := range []string{"BTC-USD", "ETH-USD"} {
+		require.Len(t, updates[market], 2)
+		snapshot, delta := updates[market][0], updates[market][1]
+		assert.Equal(t, OrderbookUpdateSnapshot, snapshot.Type)
+		assert.Equal(t, "42999", snapshot.Bid[0].Price.String())
+		assert.Equal(t, "0.3", snapshot.Ask[0].Qty.String())
+		assert.Equal(t, OrderbookUpdateDelta, delta.Type)
+		assert.Equal(t, int64(2), delta.Seq)
+		assert.Equal(t, "-0.2", delta.Bid[0].Qty.String())
+		assert.Empty(t, delta.Ask)
+	}
+}
+
+func TestStreamClient_SequenceGapResnapshots(t *testing.T) {
+	server := newTestStreamServer(t, func(r *http.Request, conn *websocket.Conn, attempt int) {
+		if attempt == 0 {
+			conn.WriteMessage(websocket.TextMessage, []byte(orderbookMessage("SNAPSHOT", 1, "BTC-USD", `{"p":"42999","q":"0.5"}`, "")))
+			// seq 2 is lost
+			conn.WriteMessage(websocket.TextMessage, []byte(orderbookMessage("DELTA", 3, "BTC-USD", `{"p":"42999","q":"0.1"}`, "")))
+			return
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(orderbookMessage("SNAPSHOT", 1, "BTC-USD", `{"p":"42998","q":"1"}`, "")))
+	})
+
+	sub, err := server.client().SubscribeOrderbooks(context.Background(), "BTC-USD")
+	require.NoError(t, err)
+	defer sub.Close()
+
+	first := receive(t, sub)
+	assert.Equal(t, OrderbookUpdateSnapshot, first.Type)
+
+	second := receive(t, sub)
+	assert.Equal(t, OrderbookUpdateSnapshot, second.Type, "The delta after the gap should be dropped in favour of a new snapshot")
+	assert.Equal(t, "42998", second.Bid[0].Price.String())
+	assert.Equal(t, 2, server.connectionCount("/orderbooks/BTC-USD"))
+
+	disconnected := receiveEvent(t, sub)
+	assert.Equal(t, StreamEventDisconnected, disconnected.Type)
+	assert.ErrorIs(t, disconnected.Err, ErrStreamSequenceGap)
+	assert.Equal(t, StreamEventReconnected, receiveEvent(t, sub).Type)
+}
+
+func TestStreamClient_ContextCancelEndsSubscription(t *testing.T) {
+	server := newTestStreamServer(t, func(r *http.Request, conn *websocket.Conn, attempt int) {
+		assert.Equal(t, "/orderbooks", r.URL.Path)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := server.client().SubscribeOrderbooks(ctx)
+	require.NoError(t, err)
+
+	cancel()
+	select {
+	case <-sub.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Subscription did not end after its context was canceled")
+	}
+	_, ok := <-sub.C
+	assert.False(t, ok)
+	assert.NoError(t, sub.Err())
+}
+
+func TestStreamClient_StreamErrorEndsSubscription(t *testing.T) {
+	server := newTestStreamServer(t, func(r *http.Request, conn *websocket.Conn, attempt int) {
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"error":"unknown market"}`))
+	})
+
+	sub, err := server.client().SubscribeOrderbooks(context.Background(), "FOO-USD")
+	require.NoError(t, err)
+
+	<-sub.Done()
+	require.Error(t, sub.Err())
+	assert.Contains(t, sub.Err().Error(), "unknown market")
+}
+
+func TestStreamClient_RequiresStreamURL(t *testing.T) {
+	_, err := NewStreamClient(EndpointConfig{}, "").SubscribeOrderbooks(context.Background())
+	assert.ErrorIs(t, err, ErrStreamURLNotSet)
+}
+
+func TestStreamClient_SubscribeAccount(t *testing.T) {
+	server := newTestStreamServer(t, func(r *http.Request, conn *websocket.Conn, attempt int) {
+		assert.Equal(t, "/account", r.URL.Path)
+		assert.Equal(t, TestAPIKey, r.Header.Get("X-API-Key"))
+		for _, msg := range []string{
+			`{"type":"ORDER","data":{"orders":[{"id":1,"externalId":"0xabc","market":"BTC-USD","type":"LIMIT","side":"BUY","status":"PARTIALLY_FILLED","price":"43000","qty":"0.2","filledQty":"0.1"}]},"ts":1,"seq":1}`,
+			`{"type":"TRADE","data":{"trades":[{"id":7,"market":"BTC-USD","orderId":1,"side":"BUY","price":"43000","qty":"0.1","fee":"0.86","isTaker":true,"tradeType":"TRADE"}]},"ts":2,"seq":2}`,
+			`{"type":"POSITION","data":{"positions":[{"market":"BTC-USD","side":"LONG","size":"0.1","openPrice":"43000"}]},"ts":3,"seq":3}`,
+			`{"type":"BALANCE","data":{"balance":{"collateralName":"USD","equity":"10000.5","availableForTrade":"9570.5"}},"ts":4,"seq":4}`,
+		} {
+			conn.WriteMessage(websocket.TextMessage, []byte(msg))
+		}
+	})
+
+	sub, err := server.client().SubscribeAccount(context.Background())
+	require.NoError(t, err)
+	defer sub.Close()
+
+	update := receive(t, sub)
+	assert.Equal(t, AccountUpdateOrder, update.Type)
+	require.Len(t, update.Orders, 1)
+	assert.Equal(t, OrderStatusPartiallyFilled, update.Orders[0].Status)
+	assert.Equal(t, "0.1", update.Orders[0].FilledQty.String())
+
+	update = receive(t, sub)
+	assert.Equal(t, AccountUpdateTrade, update.Type)
+	require.Len(t, update.Trades, 1)
+	assert.Equal(t, uint(1), update.Trades[0].OrderID)
+	assert.True(t, update.Trades[0].IsTaker)
+
+	update = receive(t, sub)
+	assert.Equal(t, AccountUpdatePosition, update.Type)
+	require.Len(t, update.Positions, 1)
+	assert.Equal(t, PositionSideLong, update.Positions[0].Side)
+
+	update = receive(t, sub)
+	assert.Equal(t, AccountUpdateBalance, update.Type)
+	require.NotNil(t, update.Balance)
+	assert.Equal(t, "9570.5", update.Balance.AvailableForTrade.String())
+	assert.Nil(t, update.Orders)
+}
+
+func TestStreamClient_SubscribeAccountRequiresAPIKey(t *testing.T) {
+	client := NewStreamClient(EndpointConfig{StreamURL: "ws://127.0.0.1:0"}, "")
+	_, err := client.SubscribeAccount(context.Background())
+	assert.ErrorIs(t, err, ErrAPIKeyNotSet)
+}
+
+func receiveEvent[T any](t *testing.T, sub *Subscription[T]) StreamEvent {
+	t.Helper()
+	select {
+	case event := <-sub.Events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for a stream event")
+	}
+	return StreamEvent{}
+}
+
+func TestStreamClient_ReconnectsAfterDisconnect(t *testing.T) {
+	server := newTestStreamServer(t, func(r *http.Request, conn *websocket.Conn, attempt int) {
+		conn.WriteMessage(websocket.TextMessage, []byte(orderbookMessage("SNAPSHOT", 1, "BTC-USD", fmt.Sprintf(`{"p":"%d","q":"1"}`, 100+attempt), "")))
+		if attempt == 0 {
+			conn.Close()
+		}
+	})
+
+	sub, err := server.client().SubscribeOrderbooks(context.Background(), "BTC-USD")
+	require.NoError(t, err)
+	defer sub.Close()
+
+	assert.Equal(t, "100", receive(t, sub).Bid[0].Price.String())
+
+	disconnected := receiveEvent(t, sub)
+	assert.Equal(t, StreamEventDisconnected, disconnected.Type)
+	assert.Equal(t, "/orderbooks/BTC-USD", disconnected.Path)
+	assert.Error(t, disconnected.Err)
+
+	reconnected := receiveEvent(t, sub)
+	assert.Equal(t, StreamEventReconnected, reconnected.Type)
+	assert.Equal(t, 1, reconnected.Attempts)
+
+	assert.Equal(t, "101", receive(t, sub).Bid[0].Price.String(), "The stream should be resubscribed after reconnecting")
+}
+
+func TestStreamClient_HeartbeatTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	server := newTestStreamServer(t, func(r *http.Request, conn *websocket.Conn, attempt int) {
+		conn.WriteMessage(websocket.TextMessage, []byte(orderbookMessage("SNAPSHOT", 1, "BTC-USD", "", "")))
+		if attempt == 0 {
+			// Stop reading, so pings are never answered
+			<-release
+		}
+	})
+
+	client := server.client()
+	client.SetHeartbeat(20*time.Millisecond, 20*time.Millisecond)
+	sub, err := client.SubscribeOrderbooks(context.Background(), "BTC-USD")
+	require.NoError(t, err)
+	defer sub.Close()
+
+	receive(t, sub)
+	disconnected := receiveEvent(t, sub)
+	assert.Equal(t, StreamEventDisconnected, disconnected.Type)
+	assert.Contains(t, disconnected.Err.Error(), "timeout")
+	assert.Equal(t, StreamEventReconnected, receiveEvent(t, sub).Type)
+	receive(t, sub)
+}
+
+func TestStreamClient_GivesUpAfterMaxAttempts(t *testing.T) {
+	release := make(chan struct{})
+	server := newTestStreamServer(t, func(r *http.Request, conn *websocket.Conn, attempt int) {
+		<-release
+		conn.Close()
+	})
+
+	client := server.client()
+	client.SetReconnectPolicy(ReconnectPolicy{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, MaxAttempts: 3})
+	sub, err := client.SubscribeOrderbooks(context.Background(), "BTC-USD")
+	require.NoError(t, err)
+
+	server.Close()
+	close(release)
+
+	select {
+	case <-sub.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Subscription did not give up reconnecting")
+	}
+	require.Error(t, sub.Err())
+	assert.Contains(t, sub.Err().Error(), "giving up after 3 reconnect attempts")
+}
+
+func TestReconnectPolicy_Backoff(t *testing.T) {
+	policy := ReconnectPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+
+	for attempt, base := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		4:  800 * time.Millisecond,
+		10: time.Second,
+	} {
+		for range 20 {
+			delay := policy.backoff(attempt)
+			assert.GreaterOrEqual(t, delay, base/2, "attempt %d", attempt)
+			assert.Less(t, delay, base, "attempt %d", attempt)
+		}
+	}
+}
+
+func TestStreamClient_MarketDataStreams(t *testing.T) {
+	server := newTestStreamServer(t, func(r *http.Request, conn *websocket.Conn, attempt int) {
+		var msg string
+		switch r.URL.Path {
+		case "/publicTrades/BTC-USD":
+			msg = `{"type":"TRADE","data":[{"i":1,"m":"BTC-USD","S":"BUY","tT":"TRADE","T":1704067200000,"p":"43000","q":"0.01"},` +
+				`{"i":2,"m":"BTC-USD","S":"SELL","tT":"LIQUIDATION","T":1704067200001,"p":"42990","q":"0.5"}],"ts":1704067200002,"seq":1}`
+		case "/candles/BTC-USD/mark-prices":
+			assert.Equal(t, "PT1M", r.URL.Query().Get("interval"))
+			msg = `{"data":[{"o":"43000","h":"43010","l":"42995","c":"43005","T":1704067200000}],"ts":1704067200500,"seq":1}`
+		case "/prices/mark/BTC-USD", "/prices/index/BTC-USD":
+			msg = `{"type":"MP","data":{"m":"BTC-USD","p":"43001.5","ts":1704067200000},"ts":1704067200000,"seq":1}`
+		case "/funding/BTC-USD":
+			msg = `{"data":{"m":"BTC-USD","T":1704070800000,"f":"0.000013"},"ts":1704067200000,"seq":1}`
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			return
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(msg))
+	})
+	client := server.client()
+	defer client.Close()
+	ctx := context.Background()
+
+	trades, err := client.SubscribePublicTrades(ctx, "BTC-USD")
+	require.NoError(t, err)
+	tradesUpdate := receive(t, trades)
+	require.Len(t, tradesUpdate.Trades, 2)
+	assert.Equal(t, TradeTypeLiquidation, tradesUpdate.Trades[1].TradeType)
+	assert.Equal(t, "0.5", tradesUpdate.Trades[1].Qty.String())
+
+	candles, err := client.SubscribeCandles(ctx, "BTC-USD", CandleTypeMarkPrices, CandleInterval1Minute)
+	require.NoError(t, err)
+	candleUpdate := receive(t, candles)
+	assert.Equal(t, "BTC-USD", candleUpdate.Market)
+	assert.Equal(t, CandleInterval1Minute, candleUpdate.Interval)
+	require.Len(t, candleUpdate.Candles, 1)
+	assert.Equal(t, "43005", candleUpdate.Candles[0].Close.String())
+
+	_, err = client.SubscribeCandles(ctx, "BTC-USD", CandleTypeTrades, "")
+	assert.Error(t, err, "Missing interval should be rejected")
+
+	markPrices, err := client.SubscribeMarkPrices(ctx, "BTC-USD")
+	require.NoError(t, err)
+	assert.Equal(t, "43001.5", receive(t, markPrices).Price.String())
+
+	indexPrices, err := client.SubscribeIndexPrices(ctx, "BTC-USD")
+	require.NoError(t, err)
+	assert.Equal(t, "BTC-USD", receive(t, indexPrices).Market)
+
+	funding, err := client.SubscribeFundingRates(ctx, "BTC-USD")
+	require.NoError(t, err)
+	fundingUpdate := receive(t, funding)
+	assert.Equal(t, "0.000013", fundingUpdate.FundingRate.String())
+	assert.Equal(t, int64(1704070800000), fundingUpdate.FundingTime)
+}
+
+func TestStreamClient_CloseEndsAllSubscriptions(t *testing.T) {
+	server := newTestStreamServer(t, func(r *http.Request, conn *websocket.Conn, attempt int) {})
+	client := server.client()
+	ctx := context.Background()
+
+	orderbooks, err := client.SubscribeOrderbooks(ctx, "BTC-USD")
+	require.NoError(t, err)
+	trades, err := client.SubscribePublicTrades(ctx)
+	require.NoError(t, err)
+
+	client.Close()
+
+	for _, done := range []<-chan struct{}{orderbooks.Done(), trades.Done()} {
+		select {
+		case <-done:
+		default:
+			t.Fatal("Subscription still running after the client was closed")
+		}
+	}
+	assert.NoError(t, orderbooks.Err())
+	assert.NoError(t, trades.Err())
+}
@@ -0,0 +1,46 @@
+package sdk
+
+import "github.com/shopspring/decimal"
+
+// TradeType distinguishes regular fills from forced closures
+type TradeType string
+
+const (
+	TradeTypeTrade       TradeType = "TRADE"
+	TradeTypeLiquidation TradeType = "LIQUIDATION"
+	TradeTypeDeleverage  TradeType = "DELEVERAGE"
+)
+
+// TradeModel represents a fill on one of the account's orders
+type TradeModel struct {
+	ID          uint            `json:"id"`
+	AccountID   uint            `json:"accountId"`
+	Market      string          `json:"market"`
+	OrderID     uint            `json:"orderId"`
+	Side        OrderSide       `json:"side"`
+	Price       decimal.Decimal `json:"price"`
+	Qty         decimal.Decimal `json:"qty"`
+	Value       decimal.Decimal `json:"value"`
+	Fee         decimal.Decimal `json:"fee"`
+	IsTaker     bool            `json:"isTaker"`
+	TradeType   TradeType       `json:"tradeType"`
+	CreatedTime int64           `json:"createdTime"`
+}
+
+// PublicTradeModel represents a trade printed on a market's public tape
+type PublicTradeModel struct {
+	ID        int64           `json:"i"`
+	Market    string          `json:"m"`
+	Side      OrderSide       `json:"S"`
+	TradeType TradeType       `json:"tT"`
+	Timestamp int64           `json:"T"`
+	Price     decimal.Decimal `json:"p"`
+	Qty       decimal.Decimal `json:"q"`
+}
+
+// PublicTradesUpdate is a batch of trades received from the public trades stream
+type PublicTradesUpdate struct {
+	Trades    []PublicTradeModel
+	Seq       int64
+	Timestamp int64
+}